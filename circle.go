@@ -0,0 +1,36 @@
+package ebimath
+
+// Circle represents a 2D circle with a center point and radius.
+type Circle struct {
+	Center Vector
+	Radius float64
+}
+
+// NewCircle creates a new circle.
+func NewCircle(center Vector, radius float64) Circle {
+	return Circle{Center: center, Radius: radius}
+}
+
+// Contains checks if a point is within the circle, including its edge.
+func (c Circle) Contains(p Vector) bool {
+	return c.Center.DistanceSquaredTo(p) <= c.Radius*c.Radius
+}
+
+// Intersects checks if two circles overlap or touch.
+func (c Circle) Intersects(other Circle) bool {
+	radii := c.Radius + other.Radius
+	return c.Center.DistanceSquaredTo(other.Center) <= radii*radii
+}
+
+// IntersectsRect checks if the circle intersects with an axis-aligned rectangle.
+func (c Circle) IntersectsRect(r Rectangle) bool {
+	return c.Contains(r.ClosestPoint(c.Center))
+}
+
+// BoundingBox returns the axis-aligned rectangle enclosing the circle.
+func (c Circle) BoundingBox() Rectangle {
+	return Rectangle{
+		Min: c.Center.SubF(c.Radius),
+		Max: c.Center.AddF(c.Radius),
+	}
+}
@@ -0,0 +1,68 @@
+package ebimath
+
+import "testing"
+
+func TestCircleContains(t *testing.T) {
+	c := NewCircle(V(0, 0), 5)
+	if !c.Contains(V(3, 4)) {
+		t.Error("Contains(edge point) = false, want true")
+	}
+	if !c.Contains(V(1, 1)) {
+		t.Error("Contains(inner point) = false, want true")
+	}
+	if c.Contains(V(4, 4)) {
+		t.Error("Contains(outer point) = true, want false")
+	}
+}
+
+func TestCircleIntersects(t *testing.T) {
+	c := NewCircle(V(0, 0), 5)
+	tests := []struct {
+		name  string
+		other Circle
+		want  bool
+	}{
+		{"touching", NewCircle(V(8, 0), 3), true},
+		{"overlapping", NewCircle(V(6, 0), 3), true},
+		{"containing", NewCircle(V(1, 1), 1), true},
+		{"separate", NewCircle(V(9, 0), 3), false},
+	}
+	for _, tt := range tests {
+		if got := c.Intersects(tt.other); got != tt.want {
+			t.Errorf("%s: Intersects = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := tt.other.Intersects(c); got != tt.want {
+			t.Errorf("%s: reversed Intersects = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCircleIntersectsRect(t *testing.T) {
+	r := NewRectangle(0, 0, 10, 10)
+	tests := []struct {
+		name string
+		c    Circle
+		want bool
+	}{
+		{"touching edge", NewCircle(V(-2, 5), 2), true},
+		{"overlapping corner", NewCircle(V(-1, -1), 2), true},
+		{"containing rect", NewCircle(V(5, 5), 20), true},
+		{"inside rect", NewCircle(V(5, 5), 1), true},
+		{"near corner", NewCircle(V(-2, -2), 2), false},
+	}
+	for _, tt := range tests {
+		if got := tt.c.IntersectsRect(r); got != tt.want {
+			t.Errorf("%s: IntersectsRect = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := r.IntersectsCircle(tt.c.Center, tt.c.Radius); got != tt.want {
+			t.Errorf("%s: Rectangle.IntersectsCircle = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCircleBoundingBox(t *testing.T) {
+	got := NewCircle(V(1, -2), 3).BoundingBox()
+	if want := NewRectangle(-2, -5, 4, 1); got != want {
+		t.Errorf("BoundingBox = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,83 @@
+package ebimath
+
+import "math"
+
+// Bézier Curves
+// -------------
+// QuadraticBezier evaluates a quadratic Bézier curve at t, where p0 and p2 are
+// the endpoints and p1 is the control point.
+func QuadraticBezier(p0, p1, p2 Vector, t float64) Vector {
+	u := 1 - t
+	return p0.ScaleF(u*u).Add(
+		p1.ScaleF(2*u*t),
+		p2.ScaleF(t*t),
+	)
+}
+
+// CubicBezier evaluates a cubic Bézier curve at t, where p0 and p3 are
+// the endpoints and p1 and p2 are the control points.
+func CubicBezier(p0, p1, p2, p3 Vector, t float64) Vector {
+	u := 1 - t
+	return p0.ScaleF(u*u*u).Add(
+		p1.ScaleF(3*u*u*t),
+		p2.ScaleF(3*u*t*t),
+		p3.ScaleF(t*t*t),
+	)
+}
+
+// CubicBezierDerivative returns the tangent of a cubic Bézier curve at t.
+// The result is not normalized; its length is the speed along the curve.
+func CubicBezierDerivative(p0, p1, p2, p3 Vector, t float64) Vector {
+	u := 1 - t
+	return p1.Sub(p0).ScaleF(3*u*u).Add(
+		p2.Sub(p1).ScaleF(6*u*t),
+		p3.Sub(p2).ScaleF(3*t*t),
+	)
+}
+
+// Catmull-Rom Splines
+// -------------------
+// CatmullRom evaluates a Catmull-Rom segment at t. The curve passes through p1
+// at t=0 and p2 at t=1, with tangents derived from p0 and p3.
+func CatmullRom(p0, p1, p2, p3 Vector, t float64) Vector {
+	return V(
+		CubicInterpolate(p1.X, p2.X, p0.X, p3.X, t),
+		CubicInterpolate(p1.Y, p2.Y, p0.Y, p3.Y, t),
+	)
+}
+
+// CatmullRomSpline evaluates a Catmull-Rom spline through all points, mapping a
+// global t in [0, 1] across the whole list. t is clamped, and the first and last
+// points are duplicated to provide tangents at the ends.
+// Returns the zero vector if points is empty.
+func CatmullRomSpline(points []Vector, t float64) Vector {
+	n := len(points)
+	if n == 0 {
+		return ZeroVector
+	}
+	if n == 1 {
+		return points[0]
+	}
+
+	segments := n - 1
+	scaled := Clamp(t, 0, 1) * float64(segments)
+	i := min(int(scaled), segments-1)
+	local := scaled - float64(i)
+
+	p0 := points[max(i-1, 0)]
+	p3 := points[min(i+2, n-1)]
+	return CatmullRom(p0, points[i], points[i+1], p3, local)
+}
+
+// Parametric Paths
+// ----------------
+// LissajousPoint returns the point on a Lissajous figure at parameter t, with
+// frequencies a and b and phase shift delta. The output lies within [-1, 1] on both axes.
+func LissajousPoint(a, b, delta, t float64) Vector {
+	return V(math.Sin(a*t+delta), math.Sin(b*t))
+}
+
+// ArchimedeanSpiralPoint returns the point on the spiral r = a + b*theta at angle theta.
+func ArchimedeanSpiralPoint(a, b, theta float64) Vector {
+	return AngleToVector(theta, a+b*theta)
+}
@@ -0,0 +1,91 @@
+package ebimath
+
+import "testing"
+
+func TestBezierEndpoints(t *testing.T) {
+	p0, p1, p2, p3 := V(0, 0), V(1, 2), V(3, 2), V(4, 0)
+	if got := QuadraticBezier(p0, p1, p2, 0); !vectorApproxEqual(got, p0) {
+		t.Errorf("QuadraticBezier(t=0) = %v, want %v", got, p0)
+	}
+	if got := QuadraticBezier(p0, p1, p2, 1); !vectorApproxEqual(got, p2) {
+		t.Errorf("QuadraticBezier(t=1) = %v, want %v", got, p2)
+	}
+	if got := CubicBezier(p0, p1, p2, p3, 0); !vectorApproxEqual(got, p0) {
+		t.Errorf("CubicBezier(t=0) = %v, want %v", got, p0)
+	}
+	if got := CubicBezier(p0, p1, p2, p3, 1); !vectorApproxEqual(got, p3) {
+		t.Errorf("CubicBezier(t=1) = %v, want %v", got, p3)
+	}
+}
+
+func TestBezierMidpointSymmetry(t *testing.T) {
+	// Control points mirrored around x=2 put the midpoint on the mirror axis.
+	if got := QuadraticBezier(V(0, 0), V(2, 4), V(4, 0), 0.5); !vectorApproxEqual(got, V(2, 2)) {
+		t.Errorf("QuadraticBezier(t=0.5) = %v, want %v", got, V(2, 2))
+	}
+	p0, p1, p2, p3 := V(0, 0), V(1, 2), V(3, 2), V(4, 0)
+	if got := CubicBezier(p0, p1, p2, p3, 0.5); !vectorApproxEqual(got, V(2, 1.5)) {
+		t.Errorf("CubicBezier(t=0.5) = %v, want %v", got, V(2, 1.5))
+	}
+	// The tangent at the midpoint of a symmetric curve is horizontal.
+	if got := CubicBezierDerivative(p0, p1, p2, p3, 0.5); !approxEqual(got.Y, 0) || got.X <= 0 {
+		t.Errorf("CubicBezierDerivative(t=0.5) = %v, want horizontal towards +X", got)
+	}
+	// At the start, the tangent points towards the first control point.
+	if got := CubicBezierDerivative(p0, p1, p2, p3, 0); !vectorApproxEqual(got, p1.Sub(p0).ScaleF(3)) {
+		t.Errorf("CubicBezierDerivative(t=0) = %v, want %v", got, p1.Sub(p0).ScaleF(3))
+	}
+}
+
+func TestCatmullRomPassesThroughControlPoints(t *testing.T) {
+	p0, p1, p2, p3 := V(-1, 0), V(0, 1), V(2, 3), V(4, 0)
+	if got := CatmullRom(p0, p1, p2, p3, 0); !vectorApproxEqual(got, p1) {
+		t.Errorf("CatmullRom(t=0) = %v, want %v", got, p1)
+	}
+	if got := CatmullRom(p0, p1, p2, p3, 1); !vectorApproxEqual(got, p2) {
+		t.Errorf("CatmullRom(t=1) = %v, want %v", got, p2)
+	}
+}
+
+func TestCatmullRomSpline(t *testing.T) {
+	points := []Vector{V(0, 0), V(1, 2), V(3, 1), V(4, 4), V(6, 0)}
+	segments := float64(len(points) - 1)
+	for i, p := range points {
+		if got := CatmullRomSpline(points, float64(i)/segments); !vectorApproxEqual(got, p) {
+			t.Errorf("CatmullRomSpline at point %d = %v, want %v", i, got, p)
+		}
+	}
+	if got := CatmullRomSpline(points, -1); !vectorApproxEqual(got, points[0]) {
+		t.Errorf("CatmullRomSpline(t=-1) = %v, want %v", got, points[0])
+	}
+	if got := CatmullRomSpline(points, 2); !vectorApproxEqual(got, points[len(points)-1]) {
+		t.Errorf("CatmullRomSpline(t=2) = %v, want %v", got, points[len(points)-1])
+	}
+	if got := CatmullRomSpline(nil, 0.5); got != ZeroVector {
+		t.Errorf("CatmullRomSpline(nil) = %v, want zero", got)
+	}
+	if got := CatmullRomSpline(points[:1], 0.5); got != points[0] {
+		t.Errorf("CatmullRomSpline(single) = %v, want %v", got, points[0])
+	}
+}
+
+func TestLissajousPoint(t *testing.T) {
+	if got := LissajousPoint(3, 2, Pi/2, 0); !vectorApproxEqual(got, V(1, 0)) {
+		t.Errorf("LissajousPoint(t=0) = %v, want %v", got, V(1, 0))
+	}
+	for i := 0; i <= 1000; i++ {
+		p := LissajousPoint(5, 4, 0.3, float64(i)*0.01)
+		if p.X < -1 || p.X > 1 || p.Y < -1 || p.Y > 1 {
+			t.Fatalf("LissajousPoint left [-1, 1]: %v", p)
+		}
+	}
+}
+
+func TestArchimedeanSpiralPoint(t *testing.T) {
+	if got := ArchimedeanSpiralPoint(2, 1, 0); !vectorApproxEqual(got, V(2, 0)) {
+		t.Errorf("ArchimedeanSpiralPoint(theta=0) = %v, want %v", got, V(2, 0))
+	}
+	if got := ArchimedeanSpiralPoint(0, 1, 2*Pi).Length(); !approxEqual(got, 2*Pi) {
+		t.Errorf("radius after one turn = %v, want %v", got, 2*Pi)
+	}
+}
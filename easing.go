@@ -0,0 +1,195 @@
+package ebimath
+
+import "math"
+
+// EasingFunc maps a normalized time t in [0, 1] to an eased progress value.
+// Every easing returns 0 at t=0 and 1 at t=1. Back and elastic easings overshoot
+// the [0, 1] range in between.
+type EasingFunc func(t float64) float64
+
+// Easings looks up the standard easing functions by name, e.g. Easings["EaseOutCubic"].
+var Easings = map[string]EasingFunc{
+	"Linear":           EaseLinear,
+	"EaseInQuad":       EaseInQuad,
+	"EaseOutQuad":      EaseOutQuad,
+	"EaseInOutQuad":    EaseInOutQuad,
+	"EaseInCubic":      EaseInCubic,
+	"EaseOutCubic":     EaseOutCubic,
+	"EaseInOutCubic":   EaseInOutCubic,
+	"EaseInSine":       EaseInSine,
+	"EaseOutSine":      EaseOutSine,
+	"EaseInOutSine":    EaseInOutSine,
+	"EaseInExpo":       EaseInExpo,
+	"EaseOutExpo":      EaseOutExpo,
+	"EaseInOutExpo":    EaseInOutExpo,
+	"EaseInBack":       EaseInBack,
+	"EaseOutBack":      EaseOutBack,
+	"EaseInOutBack":    EaseInOutBack,
+	"EaseInElastic":    EaseInElastic,
+	"EaseOutElastic":   EaseOutElastic,
+	"EaseInOutElastic": EaseInOutElastic,
+}
+
+const (
+	// easeBackC1 controls the overshoot amount of the back easings (about 10%).
+	easeBackC1 = 1.70158
+	easeBackC2 = easeBackC1 * 1.525
+	easeBackC3 = easeBackC1 + 1
+	// easeElasticC4 and easeElasticC5 set the oscillation period of the elastic easings.
+	easeElasticC4 = (2 * Pi) / 3
+	easeElasticC5 = (2 * Pi) / 4.5
+)
+
+// Linear
+// ------
+// EaseLinear returns t unchanged.
+func EaseLinear(t float64) float64 {
+	return t
+}
+
+// Quadratic
+// ---------
+// EaseInQuad accelerates from zero velocity.
+func EaseInQuad(t float64) float64 {
+	return t * t
+}
+
+// EaseOutQuad decelerates to zero velocity.
+func EaseOutQuad(t float64) float64 {
+	return 1 - (1-t)*(1-t)
+}
+
+// EaseInOutQuad accelerates until halfway, then decelerates.
+func EaseInOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 2)/2
+}
+
+// Cubic
+// -----
+// EaseInCubic accelerates from zero velocity.
+func EaseInCubic(t float64) float64 {
+	return t * t * t
+}
+
+// EaseOutCubic decelerates to zero velocity.
+func EaseOutCubic(t float64) float64 {
+	return 1 - math.Pow(1-t, 3)
+}
+
+// EaseInOutCubic accelerates until halfway, then decelerates.
+func EaseInOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	return 1 - math.Pow(-2*t+2, 3)/2
+}
+
+// Sine
+// ----
+// EaseInSine accelerates following a sine curve.
+func EaseInSine(t float64) float64 {
+	return 1 - math.Cos(t*Pi/2)
+}
+
+// EaseOutSine decelerates following a sine curve.
+func EaseOutSine(t float64) float64 {
+	return math.Sin(t * Pi / 2)
+}
+
+// EaseInOutSine accelerates and decelerates following a sine curve.
+func EaseInOutSine(t float64) float64 {
+	return -(math.Cos(Pi*t) - 1) / 2
+}
+
+// Exponential
+// -----------
+// EaseInExpo accelerates exponentially. It returns exactly 0 at t=0.
+func EaseInExpo(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	return math.Pow(2, 10*t-10)
+}
+
+// EaseOutExpo decelerates exponentially. It returns exactly 1 at t=1.
+func EaseOutExpo(t float64) float64 {
+	if t >= 1 {
+		return 1
+	}
+	return 1 - math.Pow(2, -10*t)
+}
+
+// EaseInOutExpo accelerates and decelerates exponentially.
+func EaseInOutExpo(t float64) float64 {
+	switch {
+	case t <= 0:
+		return 0
+	case t >= 1:
+		return 1
+	case t < 0.5:
+		return math.Pow(2, 20*t-10) / 2
+	default:
+		return (2 - math.Pow(2, -20*t+10)) / 2
+	}
+}
+
+// Back
+// ----
+// EaseInBack pulls back slightly below 0 before accelerating towards 1.
+func EaseInBack(t float64) float64 {
+	return easeBackC3*t*t*t - easeBackC1*t*t
+}
+
+// EaseOutBack overshoots slightly above 1 before settling.
+func EaseOutBack(t float64) float64 {
+	return 1 + easeBackC3*math.Pow(t-1, 3) + easeBackC1*math.Pow(t-1, 2)
+}
+
+// EaseInOutBack pulls back below 0 at the start and overshoots above 1 at the end.
+func EaseInOutBack(t float64) float64 {
+	if t < 0.5 {
+		return (math.Pow(2*t, 2) * ((easeBackC2+1)*2*t - easeBackC2)) / 2
+	}
+	return (math.Pow(2*t-2, 2)*((easeBackC2+1)*(t*2-2)+easeBackC2) + 2) / 2
+}
+
+// Elastic
+// -------
+// EaseInElastic oscillates around 0 with growing amplitude before snapping to 1.
+func EaseInElastic(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	if t >= 1 {
+		return 1
+	}
+	return -math.Pow(2, 10*t-10) * math.Sin((t*10-10.75)*easeElasticC4)
+}
+
+// EaseOutElastic overshoots 1 and oscillates with decaying amplitude until it settles.
+func EaseOutElastic(t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	if t >= 1 {
+		return 1
+	}
+	return math.Pow(2, -10*t)*math.Sin((t*10-0.75)*easeElasticC4) + 1
+}
+
+// EaseInOutElastic oscillates around 0 at the start and around 1 at the end.
+func EaseInOutElastic(t float64) float64 {
+	switch {
+	case t <= 0:
+		return 0
+	case t >= 1:
+		return 1
+	case t < 0.5:
+		return -(math.Pow(2, 20*t-10) * math.Sin((20*t-11.125)*easeElasticC5)) / 2
+	default:
+		return (math.Pow(2, -20*t+10)*math.Sin((20*t-11.125)*easeElasticC5))/2 + 1
+	}
+}
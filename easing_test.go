@@ -0,0 +1,51 @@
+package ebimath
+
+import "testing"
+
+func TestEasingEndpoints(t *testing.T) {
+	for name, ease := range Easings {
+		if got := ease(0); !approxEqual(got, 0) {
+			t.Errorf("%s(0) = %v, want 0", name, got)
+		}
+		if got := ease(1); !approxEqual(got, 1) {
+			t.Errorf("%s(1) = %v, want 1", name, got)
+		}
+	}
+}
+
+func TestEasingOvershoot(t *testing.T) {
+	tests := []struct {
+		name  string
+		ease  EasingFunc
+		t     float64
+		below bool
+	}{
+		{"EaseInBack", EaseInBack, 0.2, true},
+		{"EaseOutBack", EaseOutBack, 0.8, false},
+		{"EaseInElastic", EaseInElastic, 0.9, true},
+		{"EaseOutElastic", EaseOutElastic, 0.1, false},
+	}
+	for _, tt := range tests {
+		got := tt.ease(tt.t)
+		if tt.below && got >= 0 {
+			t.Errorf("%s(%v) = %v, want below 0", tt.name, tt.t, got)
+		}
+		if !tt.below && got <= 1 {
+			t.Errorf("%s(%v) = %v, want above 1", tt.name, tt.t, got)
+		}
+	}
+}
+
+func TestEasingsMap(t *testing.T) {
+	if len(Easings) != 19 {
+		t.Errorf("Easings has %d entries, want 19", len(Easings))
+	}
+	if got := Easings["EaseInQuad"](0.5); got != EaseInQuad(0.5) {
+		t.Errorf("Easings[\"EaseInQuad\"](0.5) = %v, want %v", got, EaseInQuad(0.5))
+	}
+	for _, ease := range []EasingFunc{EaseInOutQuad, EaseInOutCubic, EaseInOutSine} {
+		if got := ease(0.5); !approxEqual(got, 0.5) {
+			t.Errorf("in-out easing at 0.5 = %v, want 0.5", got)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package ebimath
+
+import "math"
+
+// Matrix Utilities
+// ----------------
+// NewMatrix builds a matrix that applies scale, then rotation, then translation,
+// in the same order as Transform.Matrix().
+func NewMatrix(translation Vector, rotation float64, scale Vector) Matrix {
+	m := Matrix{}
+	m.Scale(scale.X, scale.Y)
+	m.Rotate(rotation)
+	m.Translate(translation.X, translation.Y)
+	return m
+}
+
+// DecomposeMatrix extracts translation, rotation, and scale from a matrix built in
+// scale, rotate, translate order (the order used by Transform.Matrix()).
+// A reflection is reported as a negative Y scale. Shear is not recoverable and is discarded.
+func DecomposeMatrix(m Matrix) (translation Vector, rotation float64, scale Vector) {
+	a, b, tx := m.Element(0, 0), m.Element(0, 1), m.Element(0, 2)
+	c, d, ty := m.Element(1, 0), m.Element(1, 1), m.Element(1, 2)
+
+	translation = V(tx, ty)
+	scale.X = math.Hypot(a, c)
+	if scale.X == 0 {
+		// The X axis collapsed; derive rotation from the Y axis instead.
+		scale.Y = math.Hypot(b, d)
+		rotation = math.Atan2(-b, d)
+		return
+	}
+	rotation = math.Atan2(c, a)
+	// The determinant equals scaleX * scaleY, so its sign carries any reflection.
+	scale.Y = (a*d - b*c) / scale.X
+	return
+}
+
+// LerpMatrix interpolates between two matrices by decomposing each into translation,
+// rotation, and scale, interpolating those components, and recomposing. Rotation follows
+// the shortest path. This avoids the shearing caused by lerping coefficients directly.
+func LerpMatrix(a, b Matrix, t float64) Matrix {
+	ta, ra, sa := DecomposeMatrix(a)
+	tb, rb, sb := DecomposeMatrix(b)
+	return NewMatrix(ta.Lerp(tb, t), LerpAngle(ra, rb, t), sa.Lerp(sb, t))
+}
+
+// TransformRectangle applies a matrix to each corner of a rectangle, returning the
+// resulting quad in the same order as Rectangle.GetCorners. The rectangle's own Angle
+// is applied around its center before m, so pass an Angle of 0 to transform the
+// axis-aligned bounds only. The quad may be rotated or sheared.
+func TransformRectangle(m Matrix, r Rectangle) [4]Vector {
+	corners := r.GetCorners()
+	for i := range corners {
+		corners[i] = corners[i].Apply(m)
+	}
+	return corners
+}
@@ -0,0 +1,110 @@
+package ebimath
+
+import "testing"
+
+func TestDecomposeMatrix(t *testing.T) {
+	tests := []struct {
+		name        string
+		translation Vector
+		rotation    float64
+		scale       Vector
+	}{
+		{"identity", V(0, 0), 0, V(1, 1)},
+		{"uniform", V(10, -4), Pi / 6, V(2, 2)},
+		{"non-uniform", V(-3, 7), -2.1, V(0.5, 3)},
+		{"reflected", V(1, 2), 0.7, V(2, -1.5)},
+	}
+	for _, tt := range tests {
+		m := NewMatrix(tt.translation, tt.rotation, tt.scale)
+		translation, rotation, scale := DecomposeMatrix(m)
+		if !vectorApproxEqual(translation, tt.translation) {
+			t.Errorf("%s: translation = %v, want %v", tt.name, translation, tt.translation)
+		}
+		if !approxEqual(DeltaAngle(rotation, tt.rotation), 0) {
+			t.Errorf("%s: rotation = %v, want %v", tt.name, rotation, tt.rotation)
+		}
+		if !vectorApproxEqual(scale, tt.scale) {
+			t.Errorf("%s: scale = %v, want %v", tt.name, scale, tt.scale)
+		}
+	}
+}
+
+func TestDecomposeMatrixNegativeXScale(t *testing.T) {
+	// A flip on X is reported as an equivalent rotation with a negative Y scale.
+	m := NewMatrix(V(5, 5), 0.3, V(-2, 3))
+	translation, rotation, scale := DecomposeMatrix(m)
+	if got := NewMatrix(translation, rotation, scale); !matrixApproxEqual(got, m) {
+		t.Errorf("recomposed matrix = %v, want %v", got, m)
+	}
+	if scale.X <= 0 || scale.Y >= 0 {
+		t.Errorf("scale = %v, want positive X and negative Y", scale)
+	}
+}
+
+// matrixApproxEqual reports whether every coefficient of two matrices is close.
+func matrixApproxEqual(a, b Matrix) bool {
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 3; j++ {
+			if !approxEqual(a.Element(i, j), b.Element(i, j)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestNewMatrixMatchesTransform(t *testing.T) {
+	tr := T()
+	tr.SetPosition(V(12, -3))
+	tr.SetRotation(0.8)
+	tr.SetScale(V(1.5, -2))
+	if got, want := NewMatrix(V(12, -3), 0.8, V(1.5, -2)), tr.Matrix(); !matrixApproxEqual(got, want) {
+		t.Errorf("NewMatrix = %v, want %v", got, want)
+	}
+}
+
+func TestLerpMatrix(t *testing.T) {
+	rotated := NewMatrix(V(10, 20), Pi/2, V(3, 3))
+	identity := Matrix{}
+
+	if got := LerpMatrix(rotated, identity, 0); !matrixApproxEqual(got, rotated) {
+		t.Errorf("t=0: %v, want %v", got, rotated)
+	}
+	if got := LerpMatrix(rotated, identity, 1); !matrixApproxEqual(got, identity) {
+		t.Errorf("t=1: %v, want identity", got)
+	}
+	mid := LerpMatrix(rotated, identity, 0.5)
+	translation, rotation, scale := DecomposeMatrix(mid)
+	if !approxEqual(rotation, Pi/4) {
+		t.Errorf("midpoint rotation = %v, want %v", rotation, Pi/4)
+	}
+	if !vectorApproxEqual(translation, V(5, 10)) || !vectorApproxEqual(scale, V(2, 2)) {
+		t.Errorf("midpoint translation, scale = %v, %v, want (5,10), (2,2)", translation, scale)
+	}
+}
+
+func TestTransformRectangle(t *testing.T) {
+	r := NewRectangle(0, 0, 4, 2)
+	if got := TransformRectangle(Matrix{}, r); got != r.GetCorners() {
+		t.Errorf("identity TransformRectangle = %v, want %v", got, r.GetCorners())
+	}
+
+	// A matrix rotation pivots around the origin, not the rectangle's center.
+	m := Matrix{}
+	m.Rotate(Pi / 2)
+	got := TransformRectangle(m, r)
+	for i, corner := range r.GetCorners() {
+		want := V(-corner.Y, corner.X)
+		if !vectorApproxEqual(got[i], want) {
+			t.Errorf("corner %d = %v, want %v", i, got[i], want)
+		}
+	}
+
+	// The rectangle's own Angle is applied around its center first.
+	rotated := r
+	rotated.Angle = Pi / 2
+	got = TransformRectangle(Matrix{}, rotated)
+	if b := BoundingBox(got[:]...); !vectorApproxEqual(b.Min, V(1, -1)) || !vectorApproxEqual(b.Max, V(3, 3)) {
+		t.Errorf("rotated rectangle bounds = %v, want (1,-1)-(3,3)", b)
+	}
+}
@@ -1,5 +1,7 @@
 package ebimath
 
+import "image"
+
 // Point represents a point in 2D space with integer coordinates.
 type Point struct {
 	X, Y int
@@ -19,3 +21,80 @@ func Pf(x, y float64) Point {
 		Y: FastFloor[float64, int](y),
 	}
 }
+
+// Arithmetic
+// ----------
+// Add returns the component-wise sum of two Points.
+func (self Point) Add(other Point) Point {
+	return P(self.X+other.X, self.Y+other.Y)
+}
+
+// Sub returns the component-wise difference of two Points.
+func (self Point) Sub(other Point) Point {
+	return P(self.X-other.X, self.Y-other.Y)
+}
+
+// Mul scales both components of the Point by an integer.
+func (self Point) Mul(scalar int) Point {
+	return P(self.X*scalar, self.Y*scalar)
+}
+
+// Eq checks if two Points have identical coordinates.
+func (self Point) Eq(other Point) bool {
+	return self.X == other.X && self.Y == other.Y
+}
+
+// ToVector converts the Point to a Vector.
+func (self Point) ToVector() Vector {
+	return VInt(self.X, self.Y)
+}
+
+// In checks if the Point lies within the rectangle, using the same semantics as Rectangle.Contains.
+func (self Point) In(r Rectangle) bool {
+	return r.Contains(self.ToVector())
+}
+
+// Grid Traversal
+// --------------
+// Neighbors4 returns the four orthogonally adjacent Points in the order
+// north, east, south, west, where north is -Y (screen coordinates).
+func (self Point) Neighbors4() [4]Point {
+	return [4]Point{
+		P(self.X, self.Y-1),
+		P(self.X+1, self.Y),
+		P(self.X, self.Y+1),
+		P(self.X-1, self.Y),
+	}
+}
+
+// Neighbors8 returns the eight surrounding Points clockwise starting from north:
+// north, north-east, east, south-east, south, south-west, west, north-west.
+func (self Point) Neighbors8() [8]Point {
+	return [8]Point{
+		P(self.X, self.Y-1),
+		P(self.X+1, self.Y-1),
+		P(self.X+1, self.Y),
+		P(self.X+1, self.Y+1),
+		P(self.X, self.Y+1),
+		P(self.X-1, self.Y+1),
+		P(self.X-1, self.Y),
+		P(self.X-1, self.Y-1),
+	}
+}
+
+// ManhattanDistance returns the number of orthogonal steps between two Points.
+func (self Point) ManhattanDistance(other Point) int {
+	return Abs(self.X-other.X) + Abs(self.Y-other.Y)
+}
+
+// Image Interop
+// -------------
+// ToImagePoint converts the Point to an image.Point.
+func (self Point) ToImagePoint() image.Point {
+	return image.Pt(self.X, self.Y)
+}
+
+// PointFromImage converts an image.Point to a Point.
+func PointFromImage(ip image.Point) Point {
+	return P(ip.X, ip.Y)
+}
@@ -0,0 +1,84 @@
+package ebimath
+
+import "testing"
+
+func TestPointArithmetic(t *testing.T) {
+	a, b := P(3, -2), P(-1, 5)
+	tests := []struct {
+		name      string
+		got, want Point
+	}{
+		{"Add", a.Add(b), P(2, 3)},
+		{"Sub", a.Sub(b), P(4, -7)},
+		{"Mul", a.Mul(3), P(9, -6)},
+		{"Mul negative", a.Mul(-1), P(-3, 2)},
+	}
+	for _, tt := range tests {
+		if !tt.got.Eq(tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+	if a.Eq(b) {
+		t.Errorf("%v.Eq(%v) = true, want false", a, b)
+	}
+	if !a.Eq(P(3, -2)) {
+		t.Errorf("%v.Eq(%v) = false, want true", a, P(3, -2))
+	}
+}
+
+func TestPointNeighbors(t *testing.T) {
+	p := P(5, 5)
+	want4 := [4]Point{P(5, 4), P(6, 5), P(5, 6), P(4, 5)}
+	if got := p.Neighbors4(); got != want4 {
+		t.Errorf("Neighbors4 = %v, want %v", got, want4)
+	}
+	want8 := [8]Point{P(5, 4), P(6, 4), P(6, 5), P(6, 6), P(5, 6), P(4, 6), P(4, 5), P(4, 4)}
+	if got := p.Neighbors8(); got != want8 {
+		t.Errorf("Neighbors8 = %v, want %v", got, want8)
+	}
+	for _, n := range p.Neighbors4() {
+		if d := p.ManhattanDistance(n); d != 1 {
+			t.Errorf("ManhattanDistance to %v = %d, want 1", n, d)
+		}
+	}
+}
+
+func TestPointManhattanDistance(t *testing.T) {
+	tests := []struct {
+		a, b Point
+		want int
+	}{
+		{P(0, 0), P(0, 0), 0},
+		{P(1, 1), P(4, 5), 7},
+		{P(4, 5), P(1, 1), 7},
+		{P(-2, 3), P(2, -3), 10},
+	}
+	for _, tt := range tests {
+		if got := tt.a.ManhattanDistance(tt.b); got != tt.want {
+			t.Errorf("%v.ManhattanDistance(%v) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestPointIn(t *testing.T) {
+	r := NewRectangle(0, 0, 10, 5)
+	tests := []struct {
+		p    Point
+		want bool
+	}{
+		{P(0, 0), true},   // min corner is inclusive
+		{P(9, 4), true},   // last cell inside
+		{P(10, 2), false}, // max edge is exclusive
+		{P(3, 5), false},
+		{P(-1, 2), false},
+		{P(3, -1), false},
+	}
+	for _, tt := range tests {
+		if got := tt.p.In(r); got != tt.want {
+			t.Errorf("%v.In(%v) = %v, want %v", tt.p, r, got, tt.want)
+		}
+		if got := r.Contains(tt.p.ToVector()); got != tt.p.In(r) {
+			t.Errorf("%v: In disagrees with Contains", tt.p)
+		}
+	}
+}
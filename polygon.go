@@ -0,0 +1,144 @@
+package ebimath
+
+import (
+	"math"
+	"sort"
+)
+
+// Triangles
+// ---------
+// Barycentric returns the barycentric weights of p relative to triangle abc, such that
+// p = a*u + b*v + c*w. For a degenerate (zero-area) triangle all weights are NaN.
+func Barycentric(p, a, b, c Vector) (u, v, w float64) {
+	v0, v1, v2 := b.Sub(a), c.Sub(a), p.Sub(a)
+	d00 := v0.Dot(v0)
+	d01 := v0.Dot(v1)
+	d11 := v1.Dot(v1)
+	d20 := v2.Dot(v0)
+	d21 := v2.Dot(v1)
+	denom := d00*d11 - d01*d01
+	if denom == 0 {
+		return math.NaN(), math.NaN(), math.NaN()
+	}
+	v = (d11*d20 - d01*d21) / denom
+	w = (d00*d21 - d01*d20) / denom
+	u = 1 - v - w
+	return u, v, w
+}
+
+// PointInTriangle checks if p lies inside or on the edge of triangle abc.
+// A degenerate (zero-area) triangle contains no points.
+func PointInTriangle(p, a, b, c Vector) bool {
+	u, v, w := Barycentric(p, a, b, c)
+	// NaN weights from a degenerate triangle fail every comparison.
+	return u >= 0 && v >= 0 && w >= 0
+}
+
+// Polygons
+// --------
+// PolygonArea returns the signed area of a polygon given as a ring of vertices,
+// using the shoelace formula. The area is positive for counter-clockwise winding and
+// negative for clockwise winding, with +Y up (the signs swap on screen, where +Y is down).
+// Fewer than three points yield 0.
+func PolygonArea(points []Vector) float64 {
+	if len(points) < 3 {
+		return 0
+	}
+	sum := 0.0
+	for i, p := range points {
+		sum += p.Cross(points[(i+1)%len(points)])
+	}
+	return sum / 2
+}
+
+// PolygonCentroid returns the center of mass of a polygon given as a ring of vertices.
+// For a polygon with zero area it returns the average of the vertices, and for an
+// empty slice the zero vector.
+func PolygonCentroid(points []Vector) Vector {
+	if len(points) == 0 {
+		return ZeroVector
+	}
+	area := PolygonArea(points)
+	if area == 0 {
+		return Vector{}.Add(points...).DivF(float64(len(points)))
+	}
+	var cx, cy float64
+	for i, p := range points {
+		q := points[(i+1)%len(points)]
+		cross := p.Cross(q)
+		cx += (p.X + q.X) * cross
+		cy += (p.Y + q.Y) * cross
+	}
+	return V(cx, cy).DivF(6 * area)
+}
+
+// PointInPolygon checks if p lies inside a polygon given as a ring of vertices, using
+// the even-odd rule, so self-intersecting polygons alternate between inside and outside.
+// Points on an edge or vertex (within Epsilon) are considered inside. Fewer than three
+// points contain nothing.
+func PointInPolygon(p Vector, polygon []Vector) bool {
+	if len(polygon) < 3 {
+		return false
+	}
+	inside := false
+	for i, a := range polygon {
+		b := polygon[(i+1)%len(polygon)]
+		if p.DistanceToSegment(a, b) <= Epsilon {
+			return true
+		}
+		// Count crossings of a ray cast towards +X. The half-open Y test makes
+		// a ray through a shared vertex count exactly one of its two edges.
+		if (a.Y > p.Y) != (b.Y > p.Y) {
+			x := a.X + (p.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+			if p.X < x {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+// ConvexHull returns the vertices of the smallest convex polygon containing all points,
+// using Andrew's monotone chain algorithm. Vertices are in counter-clockwise order with
+// +Y up (clockwise on screen). Duplicate points and points lying on a hull edge are
+// dropped. With fewer than three distinct points, the distinct points are returned.
+func ConvexHull(points []Vector) []Vector {
+	sorted := make([]Vector, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].X != sorted[j].X {
+			return sorted[i].X < sorted[j].X
+		}
+		return sorted[i].Y < sorted[j].Y
+	})
+	// Remove exact duplicates, which are adjacent after sorting.
+	unique := sorted[:0]
+	for i, p := range sorted {
+		if i == 0 || p != sorted[i-1] {
+			unique = append(unique, p)
+		}
+	}
+	if len(unique) < 3 {
+		return unique
+	}
+
+	// Build the lower hull left to right, then the upper hull right to left,
+	// popping any vertex that does not make a strict left turn.
+	hull := make([]Vector, 0, 2*len(unique))
+	for _, p := range unique {
+		for len(hull) >= 2 && hull[len(hull)-1].Sub(hull[len(hull)-2]).Cross(p.Sub(hull[len(hull)-2])) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(unique) - 2; i >= 0; i-- {
+		p := unique[i]
+		for len(hull) >= lower && hull[len(hull)-1].Sub(hull[len(hull)-2]).Cross(p.Sub(hull[len(hull)-2])) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	// The last point repeats the first.
+	return hull[:len(hull)-1]
+}
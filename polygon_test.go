@@ -0,0 +1,134 @@
+package ebimath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBarycentric(t *testing.T) {
+	a, b, c := V(0, 0), V(6, 0), V(0, 3)
+	tests := []struct {
+		name    string
+		p       Vector
+		u, v, w float64
+	}{
+		{"centroid", V(2, 1), 1.0 / 3, 1.0 / 3, 1.0 / 3},
+		{"vertex a", a, 1, 0, 0},
+		{"vertex b", b, 0, 1, 0},
+		{"vertex c", c, 0, 0, 1},
+		{"outside", V(6, 3), -1, 1, 1},
+	}
+	for _, tt := range tests {
+		u, v, w := Barycentric(tt.p, a, b, c)
+		if !approxEqual(u, tt.u) || !approxEqual(v, tt.v) || !approxEqual(w, tt.w) {
+			t.Errorf("%s: Barycentric = (%v, %v, %v), want (%v, %v, %v)", tt.name, u, v, w, tt.u, tt.v, tt.w)
+		}
+		if got, want := PointInTriangle(tt.p, a, b, c), tt.u >= 0; got != want {
+			t.Errorf("%s: PointInTriangle = %v, want %v", tt.name, got, want)
+		}
+	}
+}
+
+func TestBarycentricDegenerate(t *testing.T) {
+	a, b, c := V(0, 0), V(1, 1), V(2, 2)
+	u, v, w := Barycentric(V(1, 1), a, b, c)
+	if !math.IsNaN(u) || !math.IsNaN(v) || !math.IsNaN(w) {
+		t.Errorf("degenerate Barycentric = (%v, %v, %v), want NaN", u, v, w)
+	}
+	if PointInTriangle(V(1, 1), a, b, c) {
+		t.Error("degenerate triangle contains a point")
+	}
+}
+
+func TestPolygonAreaCentroid(t *testing.T) {
+	square := []Vector{V(0, 0), V(1, 0), V(1, 1), V(0, 1)}
+	if got := PolygonArea(square); !approxEqual(got, 1) {
+		t.Errorf("square area = %v, want 1", got)
+	}
+	if got := PolygonCentroid(square); !vectorApproxEqual(got, V(0.5, 0.5)) {
+		t.Errorf("square centroid = %v, want (0.5,0.5)", got)
+	}
+	reversed := []Vector{V(0, 1), V(1, 1), V(1, 0), V(0, 0)}
+	if got := PolygonArea(reversed); !approxEqual(got, -1) {
+		t.Errorf("reversed square area = %v, want -1", got)
+	}
+	if got := PolygonCentroid(reversed); !vectorApproxEqual(got, V(0.5, 0.5)) {
+		t.Errorf("reversed square centroid = %v, want (0.5,0.5)", got)
+	}
+
+	triangle := []Vector{V(0, 0), V(6, 0), V(0, 3)}
+	if got := PolygonArea(triangle); !approxEqual(got, 9) {
+		t.Errorf("triangle area = %v, want 9", got)
+	}
+	if got := PolygonCentroid(triangle); !vectorApproxEqual(got, V(2, 1)) {
+		t.Errorf("triangle centroid = %v, want (2,1)", got)
+	}
+
+	if got := PolygonArea(triangle[:2]); got != 0 {
+		t.Errorf("two-point area = %v, want 0", got)
+	}
+	if got := PolygonCentroid([]Vector{V(0, 0), V(2, 2), V(4, 4)}); !vectorApproxEqual(got, V(2, 2)) {
+		t.Errorf("collinear centroid = %v, want the vertex average (2,2)", got)
+	}
+	if got := PolygonCentroid(nil); got != ZeroVector {
+		t.Errorf("empty centroid = %v, want zero", got)
+	}
+}
+
+func TestPointInPolygon(t *testing.T) {
+	convex := []Vector{V(0, 0), V(4, 0), V(4, 4), V(0, 4)}
+	// A "U" shape whose notch between x=1 and x=3 is outside.
+	concave := []Vector{V(0, 0), V(4, 0), V(4, 4), V(3, 4), V(3, 1), V(1, 1), V(1, 4), V(0, 4)}
+	tests := []struct {
+		name    string
+		polygon []Vector
+		p       Vector
+		want    bool
+	}{
+		{"convex inside", convex, V(2, 2), true},
+		{"convex outside", convex, V(5, 2), false},
+		{"convex edge", convex, V(4, 2), true},
+		{"convex vertex", convex, V(0, 4), true},
+		{"concave arm", concave, V(0.5, 3), true},
+		{"concave base", concave, V(2, 0.5), true},
+		{"concave notch", concave, V(2, 3), false},
+		{"concave notch edge", concave, V(2, 1), true},
+		{"ray through vertex", concave, V(-1, 1), false},
+		{"degenerate", convex[:2], V(2, 0), false},
+	}
+	for _, tt := range tests {
+		if got := PointInPolygon(tt.p, tt.polygon); got != tt.want {
+			t.Errorf("%s: PointInPolygon(%v) = %v, want %v", tt.name, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestConvexHull(t *testing.T) {
+	points := []Vector{
+		V(0, 0), V(4, 0), V(4, 4), V(0, 4),
+		V(1, 1), V(2, 3), V(3, 2), // interior
+		V(4, 4), V(0, 0), // duplicates
+		V(2, 0), V(4, 2), // collinear with hull edges
+	}
+	hull := ConvexHull(points)
+	want := []Vector{V(0, 0), V(4, 0), V(4, 4), V(0, 4)}
+	if len(hull) != len(want) {
+		t.Fatalf("ConvexHull = %v, want %v", hull, want)
+	}
+	for i := range want {
+		if hull[i] != want[i] {
+			t.Errorf("ConvexHull = %v, want %v", hull, want)
+			break
+		}
+	}
+	if got := PolygonArea(hull); got <= 0 {
+		t.Errorf("hull area = %v, want positive (counter-clockwise)", got)
+	}
+	if points[0] != V(0, 0) || points[4] != V(1, 1) {
+		t.Error("ConvexHull modified its input")
+	}
+
+	if got := ConvexHull([]Vector{V(1, 1), V(1, 1), V(2, 2)}); len(got) != 2 {
+		t.Errorf("two distinct points hull = %v, want both points", got)
+	}
+}
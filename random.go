@@ -1,6 +1,8 @@
 package ebimath
 
 import (
+	"fmt"
+	"image/color"
 	"math"
 	"sort"
 	"time"
@@ -10,7 +12,8 @@ import (
 
 // Rand provides methods for generating random numbers with various distributions.
 type Rand struct {
-	rnd *rand.Rand
+	rnd  *rand.Rand
+	seed uint64 // Base for the stateless noise functions.
 }
 
 // Random creates a new random number generator with the current time as seed.
@@ -21,13 +24,21 @@ func Random() *Rand {
 // RandomWidthSeed initializes a random number generator with a specific seed.
 func RandomWidthSeed(seed1, seed2 int64) *Rand {
 	return &Rand{
-		rnd: rand.New(rand.NewPCG(uint64(seed1), uint64(seed2))),
+		rnd:  rand.New(rand.NewPCG(uint64(seed1), uint64(seed2))),
+		seed: noiseSeed(seed1, seed2),
 	}
 }
 
 // SetSeed sets the seed for the random number generator, allowing for reproducible randomness.
 func (self *Rand) SetSeed(seed int64) {
 	self.rnd = rand.New(rand.NewPCG(uint64(seed), uint64(seed)))
+	self.seed = noiseSeed(seed, seed)
+}
+
+// noiseSeed derives the noise seed from the generator seeds, so RandomWidthSeed and
+// SetSeed given the same seeds produce the same noise.
+func noiseSeed(seed1, seed2 int64) uint64 {
+	return uint64(seed1) ^ uint64(seed2)<<1
 }
 
 // Offset generates a random Vector within the given range for both X and Y components.
@@ -40,6 +51,20 @@ func (self *Rand) Chance(probability float64) bool {
 	return self.rnd.Float64() <= probability
 }
 
+// ChanceIn returns true with odds of 1 in n. Returns false if n is not positive.
+func (self *Rand) ChanceIn(n int) bool {
+	return self.ChanceOutOf(1, n)
+}
+
+// ChanceOutOf returns true with odds of k in n. Returns false if k or n is not
+// positive, and true if k is at least n.
+func (self *Rand) ChanceOutOf(k, n int) bool {
+	if k <= 0 || n <= 0 {
+		return false
+	}
+	return self.rnd.IntN(n) < k
+}
+
 // Bool returns a random boolean value where true has a 50% chance.
 func (self *Rand) Bool() bool {
 	return self.rnd.Float64() < 0.5
@@ -50,6 +75,32 @@ func (self *Rand) IntRange(min, max int) int {
 	return min + self.rnd.IntN(max-min+1)
 }
 
+// Dice returns the sum of count rolls of a die with the given number of sides.
+// Returns 0 if count is not positive or sides is less than 1.
+func (self *Rand) Dice(count, sides int) int {
+	if count <= 0 || sides < 1 {
+		return 0
+	}
+	sum := 0
+	for i := 0; i < count; i++ {
+		sum += self.IntRange(1, sides)
+	}
+	return sum
+}
+
+// DiceRolls returns the individual results of count rolls of a die with the given number of sides.
+// Returns nil if count is negative or sides is less than 1.
+func (self *Rand) DiceRolls(count, sides int) []int {
+	if count < 0 || sides < 1 {
+		return nil
+	}
+	rolls := make([]int, count)
+	for i := range rolls {
+		rolls[i] = self.IntRange(1, sides)
+	}
+	return rolls
+}
+
 // PositiveInt64 returns a non-negative random int64.
 func (self *Rand) PositiveInt64() int64 {
 	return self.rnd.Int64()
@@ -80,6 +131,52 @@ func (self *Rand) FloatRange(min, max float64) float64 {
 	return min + self.rnd.Float64()*(max-min)
 }
 
+// NormFloat64 returns a normally distributed float64 with mean 0 and standard deviation 1.
+func (self *Rand) NormFloat64() float64 {
+	return self.rnd.NormFloat64()
+}
+
+// Gaussian returns a normally distributed float64 with the given mean and standard deviation.
+func (self *Rand) Gaussian(mean, stddev float64) float64 {
+	return mean + self.rnd.NormFloat64()*stddev
+}
+
+// GaussianVector returns a Vector whose components are independently normally distributed.
+func (self *Rand) GaussianVector(mean, stddev Vector) Vector {
+	return V(self.Gaussian(mean.X, stddev.X), self.Gaussian(mean.Y, stddev.Y))
+}
+
+// Exponential returns an exponentially distributed float64 with the given rate,
+// such as the time between randomly occurring events. The mean is 1/rate.
+// Returns 0 if rate is not positive.
+func (self *Rand) Exponential(rate float64) float64 {
+	if rate <= 0 {
+		return 0
+	}
+	return self.rnd.ExpFloat64() / rate
+}
+
+// Poisson returns a Poisson distributed count of events for an interval with the
+// given expected number of events. Large lambdas use a normal approximation.
+// Returns 0 if lambda is not positive.
+func (self *Rand) Poisson(lambda float64) int {
+	if lambda <= 0 {
+		return 0
+	}
+	if lambda > 30 {
+		return max(0, int(math.Round(self.Gaussian(lambda, math.Sqrt(lambda)))))
+	}
+	// Knuth's algorithm: multiply uniforms until the product drops below e^-lambda.
+	limit := math.Exp(-lambda)
+	count := 0
+	product := self.rnd.Float64()
+	for product > limit {
+		count++
+		product *= self.rnd.Float64()
+	}
+	return count
+}
+
 // Rad returns a random angle in radians within the range [0, 2π).
 func (self *Rand) Rad() float64 {
 	return self.FloatRange(0, 2*math.Pi)
@@ -90,6 +187,120 @@ func (self *Rand) VectorRange(min, max Vector) Vector {
 	return min.Add(V(self.NextFloat64(max.X-min.X), self.NextFloat64(max.Y-min.Y)))
 }
 
+// UnitVector returns a random direction uniformly distributed on the unit circle.
+func (self *Rand) UnitVector() Vector {
+	return AngleToVector(self.Rad(), 1)
+}
+
+// VectorInCircle returns a point uniformly distributed inside a disk of the given radius.
+// The radial sample uses a square root so points are not biased toward the center.
+func (self *Rand) VectorInCircle(radius float64) Vector {
+	return AngleToVector(self.Rad(), radius*math.Sqrt(self.rnd.Float64()))
+}
+
+// Jitter returns base offset in a uniformly random direction by a distance uniform in
+// [0, amount]. Unlike VectorInCircle, samples are denser towards base, which suits
+// organic scattering around an anchor.
+func (self *Rand) Jitter(base Vector, amount float64) Vector {
+	return base.Add(AngleToVector(self.Rad(), self.NextFloat64(amount)))
+}
+
+// PointInRectangle returns a uniformly distributed point inside the rectangle,
+// honoring its rotation. An empty rectangle returns its Min.
+func (self *Rand) PointInRectangle(r Rectangle) Vector {
+	if r.IsEmpty() {
+		return r.Min
+	}
+	p := V(self.FloatRange(r.Min.X, r.Max.X), self.FloatRange(r.Min.Y, r.Max.Y))
+	if r.Angle == 0 {
+		return p
+	}
+	return p.RotateAround(r.Center(), r.Angle)
+}
+
+// PointInTriangle returns a point uniformly distributed over the area of triangle abc.
+func (self *Rand) PointInTriangle(a, b, c Vector) Vector {
+	// The square root keeps samples from clustering at vertex a.
+	r1 := math.Sqrt(self.rnd.Float64())
+	r2 := self.rnd.Float64()
+	return a.ScaleF(1-r1).Add(b.ScaleF(r1*(1-r2)), c.ScaleF(r1*r2))
+}
+
+// Color returns a random opaque color with uniformly distributed RGB channels.
+func (self *Rand) Color() color.RGBA {
+	v := self.rnd.Uint32()
+	return color.RGBA{R: uint8(v), G: uint8(v >> 8), B: uint8(v >> 16), A: 255}
+}
+
+// PastelColor returns a random opaque color with any hue, a saturation in [0.2, 0.4],
+// and a value (brightness) in [0.85, 1].
+func (self *Rand) PastelColor() color.RGBA {
+	return hsvToRGBA(self.FloatRange(0, 360), self.FloatRange(0.2, 0.4), self.FloatRange(0.85, 1))
+}
+
+// VibrantColor returns a random opaque color with any hue, a saturation in [0.75, 1],
+// and a value (brightness) in [0.85, 1].
+func (self *Rand) VibrantColor() color.RGBA {
+	return hsvToRGBA(self.FloatRange(0, 360), self.FloatRange(0.75, 1), self.FloatRange(0.85, 1))
+}
+
+// hsvToRGBA converts a hue in degrees and saturation and value in [0, 1] to an opaque color.
+func hsvToRGBA(h, s, v float64) color.RGBA {
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - c
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	return color.RGBA{
+		R: uint8(math.Round((r + m) * 255)),
+		G: uint8(math.Round((g + m) * 255)),
+		B: uint8(math.Round((b + m) * 255)),
+		A: 255,
+	}
+}
+
+// Noise1D returns smooth value noise in [-1, 1] at x. Random values at integer
+// coordinates are blended with SmoothStep. The result depends only on the seed and x,
+// so it is repeatable and does not advance the generator.
+func (self *Rand) Noise1D(x float64) float64 {
+	x0 := math.Floor(x)
+	ix := int64(x0)
+	t := SmoothStep(0, 1, x-x0)
+	return Lerp(self.latticeValue(ix, 0), self.latticeValue(ix+1, 0), t)
+}
+
+// Noise2D returns smooth value noise in [-1, 1] at (x, y). Random values at integer
+// lattice points are blended with SmoothStep along both axes. The result depends only
+// on the seed and coordinates, so it is repeatable and does not advance the generator.
+func (self *Rand) Noise2D(x, y float64) float64 {
+	x0, y0 := math.Floor(x), math.Floor(y)
+	ix, iy := int64(x0), int64(y0)
+	tx, ty := SmoothStep(0, 1, x-x0), SmoothStep(0, 1, y-y0)
+	top := Lerp(self.latticeValue(ix, iy), self.latticeValue(ix+1, iy), tx)
+	bottom := Lerp(self.latticeValue(ix, iy+1), self.latticeValue(ix+1, iy+1), tx)
+	return Lerp(top, bottom, ty)
+}
+
+// latticeValue hashes an integer lattice point with the seed into a value in [-1, 1].
+func (self *Rand) latticeValue(x, y int64) float64 {
+	h := mix64(self.seed ^ uint64(x)*0x9E3779B97F4A7C15 ^ uint64(y)*0xC2B2AE3D27D4EB4F)
+	// Use the top 53 bits for a uniform float in [0, 1].
+	return float64(h>>11)/(1<<53)*2 - 1
+}
+
 // RandomIndex selects a random index from a slice. Returns -1 if the slice is empty.
 func RandomIndex[T any](r *Rand, slice []T) int {
 	if len(slice) == 0 {
@@ -121,6 +332,85 @@ func RandomShuffle[T any](r *Rand, slice []T) {
 	})
 }
 
+// RandomWeightedShuffle reorders items in place so that higher-weight items tend to
+// appear earlier, using exponential keys (rand^(1/weight)) sorted in descending order.
+// Items with non-positive weights end up last. The weights slice is not modified.
+// It panics if items and weights have different lengths.
+func RandomWeightedShuffle[T any](r *Rand, items []T, weights []float64) {
+	if len(items) != len(weights) {
+		panic(fmt.Sprintf("ebimath: RandomWeightedShuffle length mismatch: %d items, %d weights", len(items), len(weights)))
+	}
+	keys := make([]float64, len(items))
+	order := make([]int, len(items))
+	for i, weight := range weights {
+		order[i] = i
+		if weight > 0 {
+			keys[i] = math.Pow(r.rnd.Float64(), 1/weight)
+		} else {
+			keys[i] = -1
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return keys[order[i]] > keys[order[j]]
+	})
+
+	shuffled := make([]T, len(items))
+	for i, index := range order {
+		shuffled[i] = items[index]
+	}
+	copy(items, shuffled)
+}
+
+// WeightedChoice selects a single item with probability proportional to its weight,
+// without building a RandPicker. Items with non-positive weights are never chosen.
+// Returns the zero value if the lengths differ or no item has a positive weight.
+func WeightedChoice[T any](r *Rand, items []T, weights []float64) (element T) {
+	if len(items) != len(weights) {
+		return element
+	}
+	total := 0.0
+	for _, weight := range weights {
+		if weight > 0 {
+			total += weight
+		}
+	}
+	if total == 0 {
+		return element
+	}
+	roll := r.rnd.Float64() * total
+	last := -1
+	for i, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+		if roll < weight {
+			return items[i]
+		}
+		roll -= weight
+		last = i
+	}
+	// Floating point residue can leave roll just past the final weight.
+	return items[last]
+}
+
+// RandomSample returns n distinct elements chosen uniformly from the slice without
+// mutating it. If n >= len(slice) a shuffled copy of the whole slice is returned;
+// if n <= 0 an empty slice is returned.
+func RandomSample[T any](r *Rand, slice []T, n int) []T {
+	if n <= 0 {
+		return []T{}
+	}
+	n = min(n, len(slice))
+	result := make([]T, len(slice))
+	copy(result, slice)
+	// Partial Fisher-Yates: only the first n positions need to be settled.
+	for i := 0; i < n; i++ {
+		j := r.IntRange(i, len(result)-1)
+		result[i], result[j] = result[j], result[i]
+	}
+	return result[:n]
+}
+
 // RandPicker for weighted random selection
 // ---------------------------------------
 type RandPicker[T any] struct {
@@ -184,14 +474,69 @@ func (self *RandPicker[T]) IsEmpty() bool {
 	return len(self.values) == 0
 }
 
+// Len returns the number of options in the picker.
+func (self *RandPicker[T]) Len() int {
+	return len(self.values)
+}
+
+// Probabilities returns the effective selection probability of each option in
+// insertion order, computed as its weight divided by the total weight.
+func (self *RandPicker[T]) Probabilities() []float64 {
+	probabilities := self.weights()
+	if self.threshold == 0 {
+		return probabilities
+	}
+	for i := range probabilities {
+		probabilities[i] /= self.threshold
+	}
+	return probabilities
+}
+
 // Pick selects a random option based on the weights provided. If no options exist, returns the zero value.
 func (self *RandPicker[T]) Pick() T {
 	var result T
-	if len(self.values) == 0 {
+	if i := self.pickIndex(); i >= 0 {
+		result = self.values[i]
+	}
+	return result
+}
+
+// PickAndRemove selects a random option like Pick and then removes it from the picker,
+// so it cannot be selected again. If no options exist, returns the zero value.
+func (self *RandPicker[T]) PickAndRemove() T {
+	var result T
+	i := self.pickIndex()
+	if i < 0 {
 		return result // Zero value
 	}
+	result = self.values[i]
+
+	weights := self.weights()
+	weights = append(weights[:i], weights[i+1:]...)
+	self.values = append(self.values[:i], self.values[i+1:]...)
+
+	// Rebuild the cumulative thresholds without the removed option.
+	self.keys = self.keys[:0]
+	self.threshold = 0
+	for index, weight := range weights {
+		self.threshold += weight
+		self.keys = append(self.keys, randPickerKey{
+			threshold: self.threshold,
+			index:     index,
+		})
+	}
+	self.sorted = false
+	return result
+}
+
+// pickIndex selects a random option index based on the weights provided.
+// Returns -1 if no options exist.
+func (self *RandPicker[T]) pickIndex() int {
+	if len(self.values) == 0 {
+		return -1
+	}
 	if len(self.values) == 1 {
-		return self.values[0]
+		return 0
 	}
 
 	// Sort keys if not already sorted
@@ -205,9 +550,20 @@ func (self *RandPicker[T]) Pick() T {
 		return roll <= self.keys[i].threshold
 	})
 	if i < len(self.keys) && roll <= self.keys[i].threshold {
-		result = self.values[self.keys[i].index]
-	} else {
-		result = self.values[len(self.values)-1]
+		return self.keys[i].index
 	}
-	return result
+	return len(self.values) - 1
+}
+
+// weights returns the individual weight of each option in insertion order.
+func (self *RandPicker[T]) weights() []float64 {
+	weights := make([]float64, len(self.values))
+	for _, key := range self.keys {
+		weights[key.index] = key.threshold
+	}
+	// Thresholds are cumulative in insertion order, so undo the running sum.
+	for i := len(weights) - 1; i > 0; i-- {
+		weights[i] -= weights[i-1]
+	}
+	return weights
 }
@@ -0,0 +1,450 @@
+package ebimath
+
+import (
+	"image/color"
+	"math"
+	"testing"
+)
+
+func TestRandPointInRectangleUniform(t *testing.T) {
+	r := RandomWidthSeed(1, 2)
+	rect := NewRectangle(-10, 0, 30, 20)
+	const bins, samples = 4, 32000
+	var counts [bins][bins]int
+	for i := 0; i < samples; i++ {
+		p := r.PointInRectangle(rect)
+		if !rect.Contains(p) {
+			t.Fatalf("PointInRectangle = %v, outside %v", p, rect)
+		}
+		bx := int((p.X - rect.Min.X) / rect.Width() * bins)
+		by := int((p.Y - rect.Min.Y) / rect.Height() * bins)
+		counts[bx][by]++
+	}
+	expected := float64(samples) / (bins * bins)
+	for x := range counts {
+		for y, n := range counts[x] {
+			if math.Abs(float64(n)-expected) > expected*0.15 {
+				t.Errorf("bin (%d, %d) has %d samples, want about %.0f", x, y, n, expected)
+			}
+		}
+	}
+}
+
+func TestRandPointInRectangleRotated(t *testing.T) {
+	r := RandomWidthSeed(3, 4)
+	rect := Rectangle{Min: V(0, 0), Max: V(10, 2), Angle: Pi / 3}
+	// Allow for rounding when rotating samples that land on an edge.
+	inflated := Rectangle{Min: rect.Min.SubF(1e-9), Max: rect.Max.AddF(1e-9), Angle: rect.Angle}
+	for i := 0; i < 1000; i++ {
+		if p := r.PointInRectangle(rect); !inflated.Contains(p) {
+			t.Fatalf("PointInRectangle = %v, outside rotated %v", p, rect)
+		}
+	}
+}
+
+func TestRandPointInRectangleEmpty(t *testing.T) {
+	rect := NewRectangle(5, 5, 5, 10)
+	if got := RandomWidthSeed(1, 2).PointInRectangle(rect); got != rect.Min {
+		t.Errorf("PointInRectangle(empty) = %v, want %v", got, rect.Min)
+	}
+}
+
+func TestRandGaussian(t *testing.T) {
+	r := RandomWidthSeed(5, 6)
+	const n = 50000
+	mean, stddev := 10.0, 3.0
+	sum, sumSquares := 0.0, 0.0
+	for i := 0; i < n; i++ {
+		x := r.Gaussian(mean, stddev)
+		sum += x
+		sumSquares += x * x
+	}
+	gotMean := sum / n
+	gotVariance := sumSquares/n - gotMean*gotMean
+	if math.Abs(gotMean-mean) > 0.1 {
+		t.Errorf("sample mean = %v, want about %v", gotMean, mean)
+	}
+	if math.Abs(gotVariance-stddev*stddev) > 0.3 {
+		t.Errorf("sample variance = %v, want about %v", gotVariance, stddev*stddev)
+	}
+}
+
+func TestRandGaussianVector(t *testing.T) {
+	r := RandomWidthSeed(7, 8)
+	const n = 20000
+	mean, stddev := V(-5, 20), V(1, 0)
+	var sum Vector
+	for i := 0; i < n; i++ {
+		v := r.GaussianVector(mean, stddev)
+		if v.Y != mean.Y {
+			t.Fatalf("GaussianVector Y = %v with zero stddev, want %v", v.Y, mean.Y)
+		}
+		sum = sum.Add(v)
+	}
+	if got := sum.DivF(n); math.Abs(got.X-mean.X) > 0.05 {
+		t.Errorf("sample mean X = %v, want about %v", got.X, mean.X)
+	}
+}
+
+func TestRandomSample(t *testing.T) {
+	r := RandomWidthSeed(9, 10)
+	deck := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	original := append([]int(nil), deck...)
+
+	for n := 0; n <= len(deck)+2; n++ {
+		sample := RandomSample(r, deck, n)
+		if want := min(n, len(deck)); len(sample) != want {
+			t.Fatalf("RandomSample(n=%d) returned %d elements, want %d", n, len(sample), want)
+		}
+		seen := map[int]bool{}
+		for _, v := range sample {
+			if seen[v] {
+				t.Fatalf("RandomSample(n=%d) = %v, duplicate %d", n, sample, v)
+			}
+			seen[v] = true
+		}
+	}
+	for i := range deck {
+		if deck[i] != original[i] {
+			t.Fatalf("input slice modified: %v, want %v", deck, original)
+		}
+	}
+	if got := RandomSample(r, deck, -1); got == nil || len(got) != 0 {
+		t.Errorf("RandomSample(n=-1) = %#v, want empty slice", got)
+	}
+}
+
+func TestRandPickerPickAndRemove(t *testing.T) {
+	picker := RandomPicker[string](RandomWidthSeed(11, 12))
+	picker.AddOption("common", 10)
+	picker.AddOption("rare", 1)
+	picker.AddOption("epic", 0.5)
+	picker.AddOption("never", 0) // ignored by AddOption
+	picker.AddOption("uncommon", 4)
+
+	seen := map[string]int{}
+	for !picker.IsEmpty() {
+		seen[picker.PickAndRemove()]++
+	}
+	for _, name := range []string{"common", "rare", "epic", "uncommon"} {
+		if seen[name] != 1 {
+			t.Errorf("%q drawn %d times, want 1", name, seen[name])
+		}
+	}
+	if len(seen) != 4 {
+		t.Errorf("drew %v, want exactly the four weighted options", seen)
+	}
+	if got := picker.PickAndRemove(); got != "" {
+		t.Errorf("PickAndRemove on empty picker = %q, want zero value", got)
+	}
+}
+
+func TestRandPickerProbabilities(t *testing.T) {
+	picker := RandomPicker[string](RandomWidthSeed(13, 14))
+	picker.AddOption("a", 1)
+	picker.AddOption("b", 3)
+	picker.AddOption("c", 4)
+
+	if got := picker.Len(); got != 3 {
+		t.Fatalf("Len = %d, want 3", got)
+	}
+	want := []float64{0.125, 0.375, 0.5}
+	got := picker.Probabilities()
+	sum := 0.0
+	for i := range want {
+		if !approxEqual(got[i], want[i]) {
+			t.Errorf("Probabilities()[%d] = %v, want %v", i, got[i], want[i])
+		}
+		sum += got[i]
+	}
+	if !approxEqual(sum, 1) {
+		t.Errorf("probabilities sum to %v, want 1", sum)
+	}
+
+	// Sorting on the first pick must not change the reported order.
+	picker.Pick()
+	if got := picker.Probabilities(); !approxEqual(got[0], want[0]) {
+		t.Errorf("Probabilities()[0] after Pick = %v, want %v", got[0], want[0])
+	}
+}
+
+func TestRandUnitVector(t *testing.T) {
+	r := RandomWidthSeed(15, 16)
+	for i := 0; i < 1000; i++ {
+		if l := r.UnitVector().Length(); !approxEqual(l, 1) {
+			t.Fatalf("UnitVector length = %v, want 1", l)
+		}
+	}
+}
+
+func TestRandVectorInCircleRadialUniformity(t *testing.T) {
+	r := RandomWidthSeed(17, 18)
+	const radius, bins, samples = 4.0, 4, 40000
+	// For a uniform disk, the squared distance from the center is uniform, so rings
+	// of equal area should receive the same number of samples.
+	var counts [bins]int
+	for i := 0; i < samples; i++ {
+		d := r.VectorInCircle(radius).LengthSquared() / (radius * radius)
+		if d > 1 {
+			t.Fatalf("VectorInCircle sample outside radius: %v", math.Sqrt(d)*radius)
+		}
+		counts[min(int(d*bins), bins-1)]++
+	}
+	expected := float64(samples) / bins
+	for i, n := range counts {
+		if math.Abs(float64(n)-expected) > expected*0.05 {
+			t.Errorf("ring %d has %d samples, want about %.0f", i, n, expected)
+		}
+	}
+}
+
+func TestRandDice(t *testing.T) {
+	r := RandomWidthSeed(19, 20)
+	for i := 0; i < 1000; i++ {
+		if got := r.Dice(3, 6); got < 3 || got > 18 {
+			t.Fatalf("Dice(3, 6) = %d, want within [3, 18]", got)
+		}
+	}
+	rolls := r.DiceRolls(100, 4)
+	if len(rolls) != 100 {
+		t.Fatalf("DiceRolls(100, 4) returned %d rolls, want 100", len(rolls))
+	}
+	for _, roll := range rolls {
+		if roll < 1 || roll > 4 {
+			t.Fatalf("DiceRolls roll = %d, want within [1, 4]", roll)
+		}
+	}
+	if got := r.Dice(0, 6); got != 0 {
+		t.Errorf("Dice(0, 6) = %d, want 0", got)
+	}
+	if got := r.DiceRolls(0, 6); len(got) != 0 {
+		t.Errorf("DiceRolls(0, 6) = %v, want no rolls", got)
+	}
+	if got := r.Dice(2, 0); got != 0 {
+		t.Errorf("Dice(2, 0) = %d, want 0", got)
+	}
+}
+
+func TestRandExponentialPoisson(t *testing.T) {
+	r := RandomWidthSeed(5, 6)
+	const samples = 20000
+	for _, rate := range []float64{0.5, 2, 10} {
+		sum := 0.0
+		for i := 0; i < samples; i++ {
+			x := r.Exponential(rate)
+			if x < 0 {
+				t.Fatalf("Exponential(%v) = %v, want non-negative", rate, x)
+			}
+			sum += x
+		}
+		if mean := sum / samples; math.Abs(mean-1/rate) > 0.05/rate {
+			t.Errorf("Exponential(%v) mean = %v, want about %v", rate, mean, 1/rate)
+		}
+	}
+	for _, lambda := range []float64{0.5, 4, 50} {
+		sum := 0
+		for i := 0; i < samples; i++ {
+			sum += r.Poisson(lambda)
+		}
+		if mean := float64(sum) / samples; math.Abs(mean-lambda) > 0.05*lambda {
+			t.Errorf("Poisson(%v) mean = %v, want about %v", lambda, mean, lambda)
+		}
+	}
+	if r.Exponential(0) != 0 || r.Poisson(-1) != 0 {
+		t.Error("non-positive parameters should return 0")
+	}
+}
+
+func TestRandomWeightedShuffle(t *testing.T) {
+	r := RandomWidthSeed(7, 8)
+	weights := []float64{1, 4, 16, 0}
+	const rounds = 5000
+	var positionSum [4]int
+	for i := 0; i < rounds; i++ {
+		items := []int{0, 1, 2, 3}
+		RandomWeightedShuffle(r, items, weights)
+		if items[3] != 3 {
+			t.Fatalf("zero-weight item not last: %v", items)
+		}
+		for pos, item := range items {
+			positionSum[item] += pos
+		}
+	}
+	// Heavier items should land earlier on average.
+	if !(positionSum[2] < positionSum[1] && positionSum[1] < positionSum[0]) {
+		t.Errorf("position sums = %v, want decreasing with weight", positionSum)
+	}
+}
+
+func TestRandomWeightedShufflePanicsOnMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("RandomWeightedShuffle did not panic on a length mismatch")
+		}
+	}()
+	RandomWeightedShuffle(RandomWidthSeed(1, 1), []int{1, 2}, []float64{1})
+}
+
+func TestRandPointInTriangle(t *testing.T) {
+	r := RandomWidthSeed(9, 10)
+	a, b, c := V(0, 0), V(9, 0), V(0, 6)
+	const samples = 20000
+	sum := ZeroVector
+	for i := 0; i < samples; i++ {
+		p := r.PointInTriangle(a, b, c)
+		if u, v, w := Barycentric(p, a, b, c); u < -1e-9 || v < -1e-9 || w < -1e-9 {
+			t.Fatalf("PointInTriangle = %v, outside the triangle", p)
+		}
+		sum = sum.Add(p)
+	}
+	centroid := a.Add(b, c).DivF(3)
+	if mean := sum.DivF(samples); mean.DistanceTo(centroid) > 0.1 {
+		t.Errorf("sample mean = %v, want about the centroid %v", mean, centroid)
+	}
+}
+
+func TestRandColors(t *testing.T) {
+	r := RandomWidthSeed(11, 12)
+	// Saturation and value of an 8-bit color, allowing for rounding.
+	hsv := func(c color.RGBA) (s, v float64) {
+		hi := float64(max(c.R, c.G, c.B))
+		lo := float64(min(c.R, c.G, c.B))
+		return (hi - lo) / hi, hi / 255
+	}
+	const tol = 2.0 / 255
+	for i := 0; i < 1000; i++ {
+		if c := r.Color(); c.A != 255 {
+			t.Fatalf("Color alpha = %d, want 255", c.A)
+		}
+		pastel := r.PastelColor()
+		s, v := hsv(pastel)
+		if pastel.A != 255 || s < 0.2-tol || s > 0.4+tol || v < 0.85-tol {
+			t.Fatalf("PastelColor %v has S=%.3f V=%.3f, want S in [0.2, 0.4], V >= 0.85", pastel, s, v)
+		}
+		vibrant := r.VibrantColor()
+		s, v = hsv(vibrant)
+		if vibrant.A != 255 || s < 0.75-tol || v < 0.85-tol {
+			t.Fatalf("VibrantColor %v has S=%.3f V=%.3f, want S >= 0.75, V >= 0.85", vibrant, s, v)
+		}
+	}
+}
+
+func TestWeightedChoice(t *testing.T) {
+	r := RandomWidthSeed(13, 14)
+	items := []string{"a", "b", "c", "never"}
+	weights := []float64{1, 2, 5, 0}
+	const samples = 16000
+	counts := map[string]int{}
+	for i := 0; i < samples; i++ {
+		counts[WeightedChoice(r, items, weights)]++
+	}
+	if counts["never"] != 0 {
+		t.Errorf("zero-weight item chosen %d times", counts["never"])
+	}
+	for i, item := range items[:3] {
+		expected := samples * weights[i] / 8
+		if math.Abs(float64(counts[item])-expected) > expected*0.1 {
+			t.Errorf("%q chosen %d times, want about %.0f", item, counts[item], expected)
+		}
+	}
+
+	if got := WeightedChoice(r, items, weights[:2]); got != "" {
+		t.Errorf("length mismatch returned %q, want the zero value", got)
+	}
+	if got := WeightedChoice(r, items, []float64{0, 0, 0, 0}); got != "" {
+		t.Errorf("all-zero weights returned %q, want the zero value", got)
+	}
+}
+
+func TestRandJitter(t *testing.T) {
+	r := RandomWidthSeed(15, 16)
+	base := V(10, -10)
+	const amount, samples, bins = 3.0, 16000, 8
+	var counts [bins]int
+	for i := 0; i < samples; i++ {
+		offset := r.Jitter(base, amount).Sub(base)
+		if offset.Length() > amount+1e-9 {
+			t.Fatalf("Jitter offset %v longer than %v", offset, amount)
+		}
+		bin := int((offset.Angle() + Pi) / (2 * Pi) * bins)
+		counts[min(bin, bins-1)]++
+	}
+	expected := float64(samples) / bins
+	for i, n := range counts {
+		if math.Abs(float64(n)-expected) > expected*0.1 {
+			t.Errorf("direction bin %d has %d samples, want about %.0f", i, n, expected)
+		}
+	}
+}
+
+func TestRandNoise(t *testing.T) {
+	a, b := RandomWidthSeed(17, 18), RandomWidthSeed(17, 18)
+	for i := 0; i < 200; i++ {
+		x, y := float64(i)*0.37-30, float64(i)*-0.21+5
+		n1, n2 := a.Noise1D(x), a.Noise2D(x, y)
+		if n1 != b.Noise1D(x) || n2 != b.Noise2D(x, y) {
+			t.Fatalf("noise at (%v, %v) is not repeatable", x, y)
+		}
+		if n1 < -1 || n1 > 1 || n2 < -1 || n2 > 1 {
+			t.Fatalf("noise at (%v, %v) = %v, %v, want within [-1, 1]", x, y, n1, n2)
+		}
+		// Adjacent samples differ by little.
+		const step = 1e-3
+		if d := math.Abs(a.Noise1D(x+step) - n1); d > 0.01 {
+			t.Errorf("Noise1D jumps by %v between %v and %v", d, x, x+step)
+		}
+		if d := math.Abs(a.Noise2D(x+step, y+step) - n2); d > 0.01 {
+			t.Errorf("Noise2D jumps by %v near (%v, %v)", d, x, y)
+		}
+	}
+	// Sampling noise does not advance the generator.
+	c := RandomWidthSeed(17, 18)
+	c.Noise2D(1.5, 2.5)
+	if c.NextFloat64(1) != RandomWidthSeed(17, 18).NextFloat64(1) {
+		t.Error("noise sampling advanced the generator")
+	}
+}
+
+func TestRandChanceIn(t *testing.T) {
+	r := RandomWidthSeed(19, 20)
+	const samples = 40000
+	tests := []struct{ k, n int }{{1, 4}, {1, 10}, {3, 5}, {7, 7}}
+	for _, tt := range tests {
+		hits := 0
+		for i := 0; i < samples; i++ {
+			var hit bool
+			if tt.k == 1 {
+				hit = r.ChanceIn(tt.n)
+			} else {
+				hit = r.ChanceOutOf(tt.k, tt.n)
+			}
+			if hit {
+				hits++
+			}
+		}
+		want := float64(tt.k) / float64(tt.n)
+		if rate := float64(hits) / samples; math.Abs(rate-want) > 0.01 {
+			t.Errorf("%d in %d: observed rate %v, want about %v", tt.k, tt.n, rate, want)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		if r.ChanceIn(0) || r.ChanceIn(-3) || r.ChanceOutOf(0, 5) || r.ChanceOutOf(1, 0) {
+			t.Fatal("non-positive odds returned true")
+		}
+	}
+}
+
+func TestRandSetSeedMatchesConstructor(t *testing.T) {
+	a := RandomWidthSeed(42, 42)
+	b := RandomWidthSeed(1, 2)
+	b.SetSeed(42)
+	for i := 0; i < 20; i++ {
+		x := float64(i) * 0.73
+		if a.Noise1D(x) != b.Noise1D(x) || a.Noise2D(x, -x) != b.Noise2D(x, -x) {
+			t.Fatalf("noise at %v differs between RandomWidthSeed and SetSeed", x)
+		}
+		if a.NextFloat64(1) != b.NextFloat64(1) {
+			t.Fatalf("stream differs between RandomWidthSeed and SetSeed at %d", i)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package ebimath
 
-import "math"
+import (
+	"image"
+	"math"
+)
 
 // Rectangle represents a 2D rectangle with min and max vectors for bounds and an orientation angle.
 type Rectangle struct {
@@ -9,6 +12,13 @@ type Rectangle struct {
 	Angle float64
 }
 
+// EmptyRectangle has inverted infinite bounds and contains nothing. It is the starting
+// value for accumulating bounds with ExpandToInclude.
+var EmptyRectangle = Rectangle{
+	Min: V2(math.Inf(1)),
+	Max: V2(math.Inf(-1)),
+}
+
 // NewRectangle creates a new axis-aligned rectangle.
 func NewRectangle(x1, y1, x2, y2 float64) Rectangle {
 	return Rectangle{
@@ -17,6 +27,30 @@ func NewRectangle(x1, y1, x2, y2 float64) Rectangle {
 	}
 }
 
+// BoundingBox creates the smallest axis-aligned rectangle containing all points.
+// With no points it returns EmptyRectangle, so the result can be grown with ExpandToInclude.
+func BoundingBox(points ...Vector) Rectangle {
+	if len(points) == 0 {
+		return EmptyRectangle
+	}
+	min, max := points[0], points[0]
+	for _, p := range points[1:] {
+		min = min.Min(p)
+		max = max.Max(p)
+	}
+	return Rectangle{Min: min, Max: max}
+}
+
+// Normalized returns a copy of the rectangle with swapped bounds corrected,
+// so that Min holds the smallest and Max the largest coordinates.
+func (r Rectangle) Normalized() Rectangle {
+	return Rectangle{
+		Min:   r.Min.Min(r.Max),
+		Max:   r.Min.Max(r.Max),
+		Angle: r.Angle,
+	}
+}
+
 // SetAngle updates the rotation angle of the rectangle.
 func (r *Rectangle) SetAngle(angle float64) {
 	r.Angle = angle
@@ -37,6 +71,25 @@ func (r Rectangle) Center() Vector {
 	return V((r.Min.X+r.Max.X)/2, (r.Min.Y+r.Max.Y)/2)
 }
 
+// Area returns the area of the rectangle.
+func (r Rectangle) Area() float64 {
+	return r.Width() * r.Height()
+}
+
+// Perimeter returns the perimeter of the rectangle.
+func (r Rectangle) Perimeter() float64 {
+	return 2 * (r.Width() + r.Height())
+}
+
+// AspectRatio returns the width divided by the height, or 0 if the height is zero.
+func (r Rectangle) AspectRatio() float64 {
+	h := r.Height()
+	if h == 0 {
+		return 0
+	}
+	return r.Width() / h
+}
+
 // X1 returns the min X coordinate.
 func (r Rectangle) X1() float64 { return r.Min.X }
 
@@ -60,9 +113,18 @@ func (r Rectangle) Equals(other Rectangle) bool {
 }
 
 // Contains checks if a point is within the rectangle.
+// Rotated rectangles are handled by testing the point in the rectangle's local space.
 func (r Rectangle) Contains(p Vector) bool {
-	return r.Min.X <= p.X && p.X < r.Max.X &&
-		r.Min.Y <= p.Y && p.Y < r.Max.Y
+	if r.Angle == 0 {
+		return r.Min.X <= p.X && p.X < r.Max.X &&
+			r.Min.Y <= p.Y && p.Y < r.Max.Y
+	}
+
+	// Undo the rotation around the center, then test against the half-extents.
+	local := p.Sub(r.Center()).Rotate(-r.Angle)
+	halfWidth, halfHeight := r.Width()/2, r.Height()/2
+	return -halfWidth <= local.X && local.X < halfWidth &&
+		-halfHeight <= local.Y && local.Y < halfHeight
 }
 
 // ContainsRect checks if one rectangle is completely inside another.
@@ -99,13 +161,92 @@ func (r Rectangle) Intersects(other Rectangle) bool {
 	return true
 }
 
+// OverlapExtent returns the penetration depth of two axis-aligned rectangles on each
+// axis, or a zero vector if they do not overlap. Resolving along the smaller component
+// separates them with the least movement. The Angle of both rectangles is ignored.
+func (r Rectangle) OverlapExtent(other Rectangle) Vector {
+	x := math.Min(r.Max.X, other.Max.X) - math.Max(r.Min.X, other.Min.X)
+	y := math.Min(r.Max.Y, other.Max.Y) - math.Max(r.Min.Y, other.Min.Y)
+	if x <= 0 || y <= 0 {
+		return ZeroVector
+	}
+	return V(x, y)
+}
+
+// OverlapArea returns the area of intersection of two axis-aligned rectangles,
+// or 0 if they do not overlap. The Angle of both rectangles is ignored.
+func (r Rectangle) OverlapArea(other Rectangle) float64 {
+	extent := r.OverlapExtent(other)
+	return extent.X * extent.Y
+}
+
 // IntersectsCircle checks if the rectangle intersects with a circle.
 func (r Rectangle) IntersectsCircle(center Vector, radius float64) bool {
-	closestX := math.Max(r.Min.X, math.Min(center.X, r.Max.X))
-	closestY := math.Max(r.Min.Y, math.Min(center.Y, r.Max.Y))
-	dx := center.X - closestX
-	dy := center.Y - closestY
-	return dx*dx+dy*dy <= radius*radius
+	return NewCircle(center, radius).IntersectsRect(r)
+}
+
+// ContainsCircle checks if a circle lies entirely inside the rectangle.
+// A circle touching an edge is not considered contained. Rotated rectangles are
+// handled by testing the center in the rectangle's local space.
+func (r Rectangle) ContainsCircle(center Vector, radius float64) bool {
+	if r.Angle == 0 {
+		return r.Min.X < center.X-radius && center.X+radius < r.Max.X &&
+			r.Min.Y < center.Y-radius && center.Y+radius < r.Max.Y
+	}
+
+	// Undo the rotation around the center, then keep the circle within the half-extents.
+	local := center.Sub(r.Center()).Rotate(-r.Angle)
+	return math.Abs(local.X)+radius < r.Width()/2 &&
+		math.Abs(local.Y)+radius < r.Height()/2
+}
+
+// IntersectsSegment checks if a segment touches or passes through the rectangle.
+// This handles rotated rectangles.
+func (r Rectangle) IntersectsSegment(s Segment) bool {
+	if r.IsEmpty() {
+		return false
+	}
+	// Endpoints are tested against the closed rectangle so segments lying along
+	// any edge are treated alike.
+	if r.containsClosed(s.Start) || r.containsClosed(s.End) {
+		return true
+	}
+
+	// Otherwise the segment must cross one of the rectangle's edges.
+	corners := r.GetCorners()
+	for i := range corners {
+		edge := NewSegment(corners[i], corners[(i+1)%len(corners)])
+		if _, ok := s.Intersects(edge); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// containsClosed checks if a point is within the rectangle or on its boundary,
+// allowing Epsilon of slack for rounding in the rotation to local space.
+func (r Rectangle) containsClosed(p Vector) bool {
+	local := p.Sub(r.Center())
+	if r.Angle != 0 {
+		local = local.Rotate(-r.Angle)
+	}
+	halfWidth, halfHeight := r.Width()/2+Epsilon, r.Height()/2+Epsilon
+	return -halfWidth <= local.X && local.X <= halfWidth &&
+		-halfHeight <= local.Y && local.Y <= halfHeight
+}
+
+// ClosestPoint returns the point within the rectangle's bounds closest to p.
+// Points inside the rectangle are returned unchanged.
+func (r Rectangle) ClosestPoint(p Vector) Vector {
+	return V(
+		math.Max(r.Min.X, math.Min(p.X, r.Max.X)),
+		math.Max(r.Min.Y, math.Min(p.Y, r.Max.Y)),
+	)
+}
+
+// DistanceToPoint returns the distance from p to the rectangle, or zero if p is inside.
+func (r Rectangle) DistanceToPoint(p Vector) float64 {
+	return p.DistanceTo(r.ClosestPoint(p))
 }
 
 // GetAxis returns one of the two axes of the rectangle based on its angle.
@@ -131,6 +272,48 @@ func (r Rectangle) GetCorners() [4]Vector {
 	}
 }
 
+// The named corner accessors assume screen coordinates, where +Y points down,
+// so the top edge lies at Min.Y. They rotate with the rectangle like GetCorners.
+
+// TopLeft returns the corner at Min before rotation (GetCorners index 2).
+func (r Rectangle) TopLeft() Vector {
+	return r.GetCorners()[2]
+}
+
+// TopRight returns the corner at (Max.X, Min.Y) before rotation (GetCorners index 3).
+func (r Rectangle) TopRight() Vector {
+	return r.GetCorners()[3]
+}
+
+// BottomLeft returns the corner at (Min.X, Max.Y) before rotation (GetCorners index 1).
+func (r Rectangle) BottomLeft() Vector {
+	return r.GetCorners()[1]
+}
+
+// BottomRight returns the corner at Max before rotation (GetCorners index 0).
+func (r Rectangle) BottomRight() Vector {
+	return r.GetCorners()[0]
+}
+
+// Edges returns the top, right, bottom, and left edges as segments, running
+// clockwise on screen from the top-left corner.
+func (r Rectangle) Edges() [4]Segment {
+	c := r.GetCorners()
+	return [4]Segment{
+		NewSegment(c[2], c[3]),
+		NewSegment(c[3], c[0]),
+		NewSegment(c[0], c[1]),
+		NewSegment(c[1], c[2]),
+	}
+}
+
+// RotatedBounds returns the axis-aligned bounding box of the rotated rectangle,
+// suitable for broad-phase culling. The result has an Angle of 0.
+func (r Rectangle) RotatedBounds() Rectangle {
+	corners := r.GetCorners()
+	return BoundingBox(corners[:]...)
+}
+
 // OverlapOnAxis checks if there's overlap along a specific axis.
 func (r Rectangle) OverlapOnAxis(other Rectangle, axis Vector) bool {
 	proj1 := r.ProjectOntoAxis(axis)
@@ -155,3 +338,113 @@ func (r Rectangle) ProjectOntoAxis(axis Vector) struct{ Min, Max float64 } {
 
 	return struct{ Min, Max float64 }{min, max}
 }
+
+// Fit scales the rectangle to fit entirely inside another while preserving its
+// aspect ratio, centered within it (letterboxing).
+func (r Rectangle) Fit(into Rectangle) Rectangle {
+	if r.IsEmpty() {
+		return r.centeredIn(into, 0)
+	}
+	return r.centeredIn(into, math.Min(into.Width()/r.Width(), into.Height()/r.Height()))
+}
+
+// Cover scales the rectangle to fully cover another while preserving its
+// aspect ratio, centered on it. Any overflow extends past into's bounds.
+func (r Rectangle) Cover(into Rectangle) Rectangle {
+	if r.IsEmpty() {
+		return r.centeredIn(into, 0)
+	}
+	return r.centeredIn(into, math.Max(into.Width()/r.Width(), into.Height()/r.Height()))
+}
+
+// centeredIn returns the rectangle scaled by a factor and centered on another.
+func (r Rectangle) centeredIn(into Rectangle, scale float64) Rectangle {
+	half := V(r.Width(), r.Height()).ScaleF(scale / 2)
+	center := into.Center()
+	return Rectangle{
+		Min: center.Sub(half),
+		Max: center.Add(half),
+	}
+}
+
+// Lerp interpolates Min, Max, and Angle towards another rectangle independently.
+// The angle follows the shortest path.
+func (r Rectangle) Lerp(to Rectangle, t float64) Rectangle {
+	return Rectangle{
+		Min:   r.Min.Lerp(to.Min, t),
+		Max:   r.Max.Lerp(to.Max, t),
+		Angle: LerpAngle(r.Angle, to.Angle, t),
+	}
+}
+
+// ScaleAround scales the rectangle by a factor while keeping pivot fixed in space.
+// A pivot at the center scales symmetrically; a pivot at a corner keeps it anchored.
+func (r Rectangle) ScaleAround(factor float64, pivot Vector) Rectangle {
+	return Rectangle{
+		Min:   pivot.Add(r.Min.Sub(pivot).ScaleF(factor)),
+		Max:   pivot.Add(r.Max.Sub(pivot).ScaleF(factor)),
+		Angle: r.Angle,
+	}
+}
+
+// Subdivide splits the rectangle into a grid of cols by rows cells, returned in
+// row-major order. Cell edges are computed from Min directly so adjacent cells
+// share exact boundaries. Returns nil if cols or rows is not positive.
+func (r Rectangle) Subdivide(cols, rows int) []Rectangle {
+	if cols <= 0 || rows <= 0 {
+		return nil
+	}
+	width, height := r.Width(), r.Height()
+	edgeX := func(i int) float64 {
+		if i == cols {
+			return r.Max.X
+		}
+		return r.Min.X + float64(i)*width/float64(cols)
+	}
+	edgeY := func(j int) float64 {
+		if j == rows {
+			return r.Max.Y
+		}
+		return r.Min.Y + float64(j)*height/float64(rows)
+	}
+
+	cells := make([]Rectangle, 0, cols*rows)
+	for j := 0; j < rows; j++ {
+		for i := 0; i < cols; i++ {
+			cells = append(cells, NewRectangle(edgeX(i), edgeY(j), edgeX(i+1), edgeY(j+1)))
+		}
+	}
+	return cells
+}
+
+// ExpandToInclude returns the smallest rectangle covering both r and p.
+// Rectangles with inverted bounds, such as EmptyRectangle, are treated as empty,
+// so expanding them yields a zero-size rectangle at p. A zero-size rectangle with
+// valid bounds, including the zero Rectangle at the origin, keeps its point.
+func (r Rectangle) ExpandToInclude(p Vector) Rectangle {
+	if r.Min.X > r.Max.X || r.Min.Y > r.Max.Y {
+		return Rectangle{Min: p, Max: p, Angle: r.Angle}
+	}
+	return Rectangle{
+		Min:   r.Min.Min(p),
+		Max:   r.Max.Max(p),
+		Angle: r.Angle,
+	}
+}
+
+// ToImageRect converts the rectangle to an image.Rectangle, rounding Min down
+// and Max up so the result covers the whole area. The Angle is ignored.
+func (r Rectangle) ToImageRect() image.Rectangle {
+	return image.Rect(
+		int(math.Floor(r.Min.X)), int(math.Floor(r.Min.Y)),
+		int(math.Ceil(r.Max.X)), int(math.Ceil(r.Max.Y)),
+	)
+}
+
+// RectFromImage converts an image.Rectangle to an axis-aligned Rectangle.
+func RectFromImage(ir image.Rectangle) Rectangle {
+	return Rectangle{
+		Min: VInt(ir.Min.X, ir.Min.Y),
+		Max: VInt(ir.Max.X, ir.Max.Y),
+	}
+}
@@ -0,0 +1,426 @@
+package ebimath
+
+import (
+	"image"
+	"math"
+	"testing"
+)
+
+func TestBoundingBox(t *testing.T) {
+	got := BoundingBox(V(-3, 2), V(4, -1), V(0, 5), V(-1, -6))
+	want := NewRectangle(-3, -6, 4, 5)
+	if got != want {
+		t.Errorf("BoundingBox = %v, want %v", got, want)
+	}
+	if got := BoundingBox(); got != EmptyRectangle || !got.IsEmpty() {
+		t.Errorf("BoundingBox() = %v, want EmptyRectangle", got)
+	}
+	if got, want := BoundingBox().ExpandToInclude(V(5, 5)), NewRectangle(5, 5, 5, 5); got != want {
+		t.Errorf("BoundingBox().ExpandToInclude = %v, want %v", got, want)
+	}
+}
+
+func TestRectangleClosestPoint(t *testing.T) {
+	r := NewRectangle(0, 0, 10, 10)
+	tests := []struct {
+		name string
+		p    Vector
+		want Vector
+		dist float64
+	}{
+		{"inside", V(3, 4), V(3, 4), 0},
+		{"on edge", V(10, 5), V(10, 5), 0},
+		{"left", V(-2, 5), V(0, 5), 2},
+		{"top left corner", V(-3, -4), V(0, 0), 5},
+		{"top right corner", V(13, -4), V(10, 0), 5},
+		{"bottom left corner", V(-3, 14), V(0, 10), 5},
+		{"bottom right corner", V(13, 14), V(10, 10), 5},
+	}
+	for _, tt := range tests {
+		if got := r.ClosestPoint(tt.p); got != tt.want {
+			t.Errorf("%s: ClosestPoint = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := r.DistanceToPoint(tt.p); !approxEqual(got, tt.dist) {
+			t.Errorf("%s: DistanceToPoint = %v, want %v", tt.name, got, tt.dist)
+		}
+	}
+}
+
+func TestRectangleContainsRotated(t *testing.T) {
+	r := Rectangle{Min: V(-4, -1), Max: V(4, 1), Angle: Pi / 6}
+	center := r.Center()
+	axisX, axisY := r.GetAxis(r.Angle, 0), r.GetAxis(r.Angle, 1)
+
+	// Brute force: project each sample onto the rectangle's axes.
+	for x := -6.0; x <= 6; x += 0.25 {
+		for y := -6.0; y <= 6; y += 0.25 {
+			p := V(x, y)
+			d := p.Sub(center)
+			u, v := d.Dot(axisX), d.Dot(axisY)
+			// Skip samples too close to an edge for the comparison to be meaningful.
+			if Abs(Abs(u)-4) < 1e-6 || Abs(Abs(v)-1) < 1e-6 {
+				continue
+			}
+			want := Abs(u) < 4 && Abs(v) < 1
+			if got := r.Contains(p); got != want {
+				t.Errorf("Contains(%v) = %v, want %v", p, got, want)
+			}
+		}
+	}
+}
+
+func TestRectangleContainsAxisAligned(t *testing.T) {
+	r := NewRectangle(0, 0, 10, 10)
+	if !r.Contains(V(0, 0)) {
+		t.Error("Contains(Min) = false, want true")
+	}
+	if r.Contains(V(10, 10)) {
+		t.Error("Contains(Max) = true, want false")
+	}
+	if !r.Contains(V(5, 5)) {
+		t.Error("Contains(center) = false, want true")
+	}
+}
+
+func TestRectangleIntersectsCircle(t *testing.T) {
+	r := NewRectangle(0, 0, 10, 10)
+	tests := []struct {
+		center Vector
+		radius float64
+		want   bool
+	}{
+		{V(5, 5), 1, true},
+		{V(12, 5), 2, true},
+		{V(12, 5), 1.5, false},
+		{V(13, 14), 5, true},
+		{V(13, 14), 4.9, false},
+	}
+	for _, tt := range tests {
+		if got := r.IntersectsCircle(tt.center, tt.radius); got != tt.want {
+			t.Errorf("IntersectsCircle(%v, %v) = %v, want %v", tt.center, tt.radius, got, tt.want)
+		}
+	}
+}
+
+func TestRectangleMeasurements(t *testing.T) {
+	r := NewRectangle(0, 0, 8, 4)
+	if got := r.Area(); got != 32 {
+		t.Errorf("Area = %v, want 32", got)
+	}
+	if got := r.Perimeter(); got != 24 {
+		t.Errorf("Perimeter = %v, want 24", got)
+	}
+	if got := r.AspectRatio(); got != 2 {
+		t.Errorf("AspectRatio = %v, want 2", got)
+	}
+
+	flat := NewRectangle(0, 3, 8, 3)
+	if got := flat.Area(); got != 0 {
+		t.Errorf("zero-height Area = %v, want 0", got)
+	}
+	if got := flat.Perimeter(); got != 16 {
+		t.Errorf("zero-height Perimeter = %v, want 16", got)
+	}
+	if got := flat.AspectRatio(); got != 0 {
+		t.Errorf("zero-height AspectRatio = %v, want 0", got)
+	}
+}
+
+func TestRectangleNormalized(t *testing.T) {
+	want := NewRectangle(1, 2, 5, 7)
+	tests := []Rectangle{
+		NewRectangle(1, 2, 5, 7),
+		NewRectangle(5, 2, 1, 7),
+		NewRectangle(1, 7, 5, 2),
+		NewRectangle(5, 7, 1, 2),
+	}
+	for _, r := range tests {
+		got := r.Normalized()
+		if got != want {
+			t.Errorf("%v.Normalized() = %v, want %v", r, got, want)
+		}
+		if got.Width() != 4 || got.Height() != 5 || got.IsEmpty() {
+			t.Errorf("%v.Normalized() has size %vx%v", r, got.Width(), got.Height())
+		}
+	}
+}
+
+func TestRectangleFitCover(t *testing.T) {
+	r := NewRectangle(0, 0, 320, 180)        // 16:9
+	into := NewRectangle(100, 100, 900, 700) // 4:3
+
+	fit := r.Fit(into)
+	if !approxEqual(fit.AspectRatio(), r.AspectRatio()) {
+		t.Errorf("Fit aspect ratio = %v, want %v", fit.AspectRatio(), r.AspectRatio())
+	}
+	if !vectorApproxEqual(fit.Center(), into.Center()) {
+		t.Errorf("Fit center = %v, want %v", fit.Center(), into.Center())
+	}
+	if !into.ContainsRect(fit) || !approxEqual(fit.Width(), into.Width()) {
+		t.Errorf("Fit = %v, want letterboxed inside %v", fit, into)
+	}
+
+	cover := r.Cover(into)
+	if !approxEqual(cover.AspectRatio(), r.AspectRatio()) {
+		t.Errorf("Cover aspect ratio = %v, want %v", cover.AspectRatio(), r.AspectRatio())
+	}
+	if !vectorApproxEqual(cover.Center(), into.Center()) {
+		t.Errorf("Cover center = %v, want %v", cover.Center(), into.Center())
+	}
+	if !cover.ContainsRect(into) || !approxEqual(cover.Height(), into.Height()) {
+		t.Errorf("Cover = %v, want cropped around %v", cover, into)
+	}
+}
+
+func TestRectangleSubdivide(t *testing.T) {
+	r := NewRectangle(0.3, -1.7, 10.1, 5.2)
+	cols, rows := 3, 7
+	cells := r.Subdivide(cols, rows)
+	if len(cells) != cols*rows {
+		t.Fatalf("Subdivide returned %d cells, want %d", len(cells), cols*rows)
+	}
+
+	area := 0.0
+	for j := 0; j < rows; j++ {
+		for i := 0; i < cols; i++ {
+			cell := cells[j*cols+i]
+			area += cell.Area()
+			// Adjacent cells share exact edges, so there are no gaps or overlaps.
+			if i > 0 && cell.Min.X != cells[j*cols+i-1].Max.X {
+				t.Errorf("cell (%d, %d) left edge %v != neighbour right edge", i, j, cell.Min.X)
+			}
+			if j > 0 && cell.Min.Y != cells[(j-1)*cols+i].Max.Y {
+				t.Errorf("cell (%d, %d) top edge %v != neighbour bottom edge", i, j, cell.Min.Y)
+			}
+		}
+	}
+	union := BoundingBox(cells[0].Min, cells[len(cells)-1].Max)
+	if union != r {
+		t.Errorf("union of cells = %v, want %v", union, r)
+	}
+	if !approxEqual(area, r.Area()) {
+		t.Errorf("total cell area = %v, want %v", area, r.Area())
+	}
+
+	if got := r.Subdivide(0, 3); got != nil {
+		t.Errorf("Subdivide(0, 3) = %v, want nil", got)
+	}
+	if got := r.Subdivide(2, -1); got != nil {
+		t.Errorf("Subdivide(2, -1) = %v, want nil", got)
+	}
+}
+
+func TestRectangleContainsCircle(t *testing.T) {
+	r := NewRectangle(0, 0, 10, 10)
+	tests := []struct {
+		name   string
+		center Vector
+		radius float64
+		want   bool
+	}{
+		{"fully inside", V(5, 5), 2, true},
+		{"touching left", V(2, 5), 2, false},
+		{"touching bottom", V(5, 8), 2, false},
+		{"crossing edge", V(9, 5), 2, false},
+		{"outside", V(20, 5), 2, false},
+		{"too large", V(5, 5), 6, false},
+	}
+	for _, tt := range tests {
+		if got := r.ContainsCircle(tt.center, tt.radius); got != tt.want {
+			t.Errorf("%s: ContainsCircle(%v, %v) = %v, want %v", tt.name, tt.center, tt.radius, got, tt.want)
+		}
+	}
+}
+
+func TestRectangleExpandToInclude(t *testing.T) {
+	r := NewRectangle(0, 0, 2, 2)
+	tests := []struct {
+		p    Vector
+		want Rectangle
+	}{
+		{V(1, 1), NewRectangle(0, 0, 2, 2)},
+		{V(-3, 1), NewRectangle(-3, 0, 2, 2)},
+		{V(5, 1), NewRectangle(0, 0, 5, 2)},
+		{V(1, -4), NewRectangle(0, -4, 2, 2)},
+		{V(1, 6), NewRectangle(0, 0, 2, 6)},
+	}
+	for _, tt := range tests {
+		if got := r.ExpandToInclude(tt.p); got != tt.want {
+			t.Errorf("ExpandToInclude(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+
+	// A zero-size rectangle at the origin is a valid point, not an empty rectangle.
+	if got, want := BoundingBox(V(0, 0)).ExpandToInclude(V(5, 5)), NewRectangle(0, 0, 5, 5); got != want {
+		t.Errorf("point at origin expanded = %v, want %v", got, want)
+	}
+	if got, want := EmptyRectangle.ExpandToInclude(V(3, 4)), NewRectangle(3, 4, 3, 4); got != want {
+		t.Errorf("EmptyRectangle expanded = %v, want %v", got, want)
+	}
+	acc := EmptyRectangle
+	for _, p := range []Vector{V(1, 5), V(-2, 3), V(4, -1)} {
+		acc = acc.ExpandToInclude(p)
+	}
+	if want := NewRectangle(-2, -1, 4, 5); acc != want {
+		t.Errorf("accumulated bounds = %v, want %v", acc, want)
+	}
+}
+
+func TestRectangleImageInterop(t *testing.T) {
+	ir := image.Rect(-3, 2, 7, 9)
+	if got := RectFromImage(ir).ToImageRect(); got != ir {
+		t.Errorf("image round trip = %v, want %v", got, ir)
+	}
+	// Fractional bounds round outwards.
+	if got, want := NewRectangle(0.5, -1.5, 3.2, 4).ToImageRect(), image.Rect(0, -2, 4, 4); got != want {
+		t.Errorf("ToImageRect = %v, want %v", got, want)
+	}
+
+	ip := image.Pt(-4, 11)
+	if got := PointFromImage(ip).ToImagePoint(); got != ip {
+		t.Errorf("point round trip = %v, want %v", got, ip)
+	}
+}
+
+func TestRectangleNamedCorners(t *testing.T) {
+	r := NewRectangle(0, 0, 4, 2)
+	if got := r.TopLeft(); !vectorApproxEqual(got, V(0, 0)) {
+		t.Errorf("TopLeft = %v, want (0,0)", got)
+	}
+	if got := r.TopRight(); !vectorApproxEqual(got, V(4, 0)) {
+		t.Errorf("TopRight = %v, want (4,0)", got)
+	}
+	if got := r.BottomLeft(); !vectorApproxEqual(got, V(0, 2)) {
+		t.Errorf("BottomLeft = %v, want (0,2)", got)
+	}
+	if got := r.BottomRight(); !vectorApproxEqual(got, V(4, 2)) {
+		t.Errorf("BottomRight = %v, want (4,2)", got)
+	}
+
+	r.Angle = 0.7
+	c := r.GetCorners()
+	named := [4]Vector{r.BottomRight(), r.BottomLeft(), r.TopLeft(), r.TopRight()}
+	if named != c {
+		t.Errorf("rotated named corners = %v, want GetCorners %v", named, c)
+	}
+
+	edges := r.Edges()
+	want := [4]Segment{
+		NewSegment(r.TopLeft(), r.TopRight()),
+		NewSegment(r.TopRight(), r.BottomRight()),
+		NewSegment(r.BottomRight(), r.BottomLeft()),
+		NewSegment(r.BottomLeft(), r.TopLeft()),
+	}
+	if edges != want {
+		t.Errorf("Edges = %v, want %v", edges, want)
+	}
+	for i, e := range edges {
+		if next := edges[(i+1)%4]; e.End != next.Start {
+			t.Errorf("edge %d does not connect to edge %d", i, (i+1)%4)
+		}
+	}
+}
+
+func TestRectangleRotatedBounds(t *testing.T) {
+	r := Rectangle{Min: V(0, 0), Max: V(2, 2), Angle: Pi / 4}
+	got := r.RotatedBounds()
+	d := math.Sqrt2
+	want := NewRectangle(1-d, 1-d, 1+d, 1+d)
+	if !vectorApproxEqual(got.Min, want.Min) || !vectorApproxEqual(got.Max, want.Max) || got.Angle != 0 {
+		t.Errorf("RotatedBounds = %v, want %v", got, want)
+	}
+
+	aligned := NewRectangle(1, 2, 5, 3)
+	if got := aligned.RotatedBounds(); !vectorApproxEqual(got.Min, aligned.Min) || !vectorApproxEqual(got.Max, aligned.Max) {
+		t.Errorf("unrotated RotatedBounds = %v, want %v", got, aligned)
+	}
+}
+
+func TestRectangleOverlap(t *testing.T) {
+	r := NewRectangle(0, 0, 10, 10)
+	tests := []struct {
+		name   string
+		other  Rectangle
+		extent Vector
+		area   float64
+	}{
+		{"partial", NewRectangle(7, 4, 15, 20), V(3, 6), 18},
+		{"contained", NewRectangle(2, 3, 5, 4), V(3, 1), 3},
+		{"containing", NewRectangle(-5, -5, 20, 20), V(10, 10), 100},
+		{"disjoint", NewRectangle(20, 0, 30, 10), ZeroVector, 0},
+		{"touching", NewRectangle(10, 0, 20, 10), ZeroVector, 0},
+	}
+	for _, tt := range tests {
+		if got := r.OverlapExtent(tt.other); !vectorApproxEqual(got, tt.extent) {
+			t.Errorf("%s: OverlapExtent = %v, want %v", tt.name, got, tt.extent)
+		}
+		if got := r.OverlapArea(tt.other); !approxEqual(got, tt.area) {
+			t.Errorf("%s: OverlapArea = %v, want %v", tt.name, got, tt.area)
+		}
+		if got := tt.other.OverlapArea(r); !approxEqual(got, tt.area) {
+			t.Errorf("%s: reversed OverlapArea = %v, want %v", tt.name, got, tt.area)
+		}
+	}
+}
+
+func TestRectangleLerp(t *testing.T) {
+	from := NewRectangle(0, 0, 10, 10)
+	to := Rectangle{Min: V(10, 20), Max: V(30, 40), Angle: 1}
+	if got := from.Lerp(to, 0); got != from {
+		t.Errorf("Lerp(t=0) = %v, want %v", got, from)
+	}
+	if got := from.Lerp(to, 1); !vectorApproxEqual(got.Min, to.Min) || !vectorApproxEqual(got.Max, to.Max) || !approxEqual(got.Angle, 1) {
+		t.Errorf("Lerp(t=1) = %v, want %v", got, to)
+	}
+	mid := from.Lerp(to, 0.5)
+	if !vectorApproxEqual(mid.Min, V(5, 10)) || !vectorApproxEqual(mid.Max, V(20, 25)) || !approxEqual(mid.Angle, 0.5) {
+		t.Errorf("Lerp(t=0.5) = %v, want (5,10)-(20,25) at 0.5", mid)
+	}
+
+	// Angles interpolate along the shortest path across the ±Pi seam.
+	a := Rectangle{Max: V(1, 1), Angle: Pi - 0.1}
+	b := Rectangle{Max: V(1, 1), Angle: -Pi + 0.1}
+	if got := a.Lerp(b, 0.5).Angle; !approxEqual(DeltaAngle(got, Pi), 0) {
+		t.Errorf("wrapped Lerp angle = %v, want Pi", got)
+	}
+}
+
+func TestRectangleScaleAround(t *testing.T) {
+	r := NewRectangle(0, 0, 4, 2)
+	centered := r.ScaleAround(2, r.Center())
+	if want := NewRectangle(-2, -1, 6, 3); centered != want {
+		t.Errorf("ScaleAround center = %v, want %v", centered, want)
+	}
+	if centered.Center() != r.Center() {
+		t.Errorf("center moved from %v to %v", r.Center(), centered.Center())
+	}
+
+	anchored := r.ScaleAround(0.5, r.Max)
+	if want := NewRectangle(2, 1, 4, 2); anchored != want {
+		t.Errorf("ScaleAround corner = %v, want %v", anchored, want)
+	}
+	if anchored.Max != r.Max {
+		t.Errorf("anchored corner moved from %v to %v", r.Max, anchored.Max)
+	}
+}
+
+func TestRectangleContainsCircleRotated(t *testing.T) {
+	// A 10x2 strip rotated a quarter turn around (5, 1) spans x in [4, 6] and y in [-4, 6].
+	r := Rectangle{Min: V(0, 0), Max: V(10, 2), Angle: Pi / 2}
+	tests := []struct {
+		name   string
+		center Vector
+		radius float64
+		want   bool
+	}{
+		{"inside along rotated length", V(5, 4), 0.5, true},
+		{"outside the rotated strip", V(8, 1), 0.5, false},
+		{"inside unrotated bounds only", V(2, 1), 0.5, false},
+		{"wider than rotated strip", V(5, 1), 1.5, false},
+	}
+	for _, tt := range tests {
+		if got := r.ContainsCircle(tt.center, tt.radius); got != tt.want {
+			t.Errorf("%s: ContainsCircle(%v, %v) = %v, want %v", tt.name, tt.center, tt.radius, got, tt.want)
+		}
+	}
+}
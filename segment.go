@@ -0,0 +1,42 @@
+package ebimath
+
+// Segment represents a 2D line segment between two points.
+type Segment struct {
+	Start, End Vector
+}
+
+// NewSegment creates a new segment from start to end.
+func NewSegment(start, end Vector) Segment {
+	return Segment{Start: start, End: end}
+}
+
+// Length returns the length of the segment.
+func (s Segment) Length() float64 {
+	return s.Start.DistanceTo(s.End)
+}
+
+// ClosestPoint returns the point on the segment closest to p.
+func (s Segment) ClosestPoint(p Vector) Vector {
+	return p.ClosestPointOnSegment(s.Start, s.End)
+}
+
+// Intersects checks if two segments cross, returning the intersection point.
+// Segments touching at an endpoint count as intersecting. Parallel and collinear
+// segments always return false, even when they overlap, since they have no
+// single intersection point.
+func (s Segment) Intersects(other Segment) (Vector, bool) {
+	r := s.End.Sub(s.Start)
+	q := other.End.Sub(other.Start)
+	denom := r.Cross(q)
+	if denom == 0 {
+		return Vector{}, false
+	}
+
+	diff := other.Start.Sub(s.Start)
+	t := diff.Cross(q) / denom
+	u := diff.Cross(r) / denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return Vector{}, false
+	}
+	return s.Start.Add(r.ScaleF(t)), true
+}
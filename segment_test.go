@@ -0,0 +1,70 @@
+package ebimath
+
+import "testing"
+
+func TestSegmentIntersects(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   Segment
+		want   bool
+		wantAt Vector
+	}{
+		{"crossing", NewSegment(V(0, 0), V(10, 10)), NewSegment(V(0, 10), V(10, 0)), true, V(5, 5)},
+		{"touching endpoints", NewSegment(V(0, 0), V(5, 5)), NewSegment(V(5, 5), V(10, 0)), true, V(5, 5)},
+		{"T junction", NewSegment(V(0, 0), V(10, 0)), NewSegment(V(5, 0), V(5, 5)), true, V(5, 0)},
+		{"parallel", NewSegment(V(0, 0), V(10, 0)), NewSegment(V(0, 1), V(10, 1)), false, Vector{}},
+		{"collinear overlap", NewSegment(V(0, 0), V(10, 0)), NewSegment(V(5, 0), V(15, 0)), false, Vector{}},
+		{"disjoint", NewSegment(V(0, 0), V(1, 1)), NewSegment(V(5, 0), V(6, -1)), false, Vector{}},
+	}
+	for _, tt := range tests {
+		at, ok := tt.a.Intersects(tt.b)
+		if ok != tt.want {
+			t.Errorf("%s: Intersects = %v, want %v", tt.name, ok, tt.want)
+			continue
+		}
+		if ok && !vectorApproxEqual(at, tt.wantAt) {
+			t.Errorf("%s: intersection at %v, want %v", tt.name, at, tt.wantAt)
+		}
+	}
+}
+
+func TestSegmentLengthAndClosestPoint(t *testing.T) {
+	s := NewSegment(V(0, 0), V(3, 4))
+	if got := s.Length(); got != 5 {
+		t.Errorf("Length = %v, want 5", got)
+	}
+	if got := s.ClosestPoint(V(-1, -1)); got != s.Start {
+		t.Errorf("ClosestPoint = %v, want %v", got, s.Start)
+	}
+}
+
+func TestRectangleIntersectsSegment(t *testing.T) {
+	r := NewRectangle(0, 0, 10, 10)
+	tests := []struct {
+		name string
+		s    Segment
+		want bool
+	}{
+		{"inside", NewSegment(V(2, 2), V(3, 3)), true},
+		{"passing through", NewSegment(V(-5, 5), V(15, 5)), true},
+		{"touching corner", NewSegment(V(-5, -5), V(0, 0)), true},
+		{"along min X edge", NewSegment(V(0, 2), V(0, 8)), true},
+		{"along max X edge", NewSegment(V(10, 2), V(10, 8)), true},
+		{"along max Y edge", NewSegment(V(2, 10), V(8, 10)), true},
+		{"outside", NewSegment(V(11, 0), V(11, 10)), false},
+	}
+	for _, tt := range tests {
+		if got := r.IntersectsSegment(tt.s); got != tt.want {
+			t.Errorf("%s: IntersectsSegment = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	rotated := Rectangle{Min: V(0, 0), Max: V(10, 10), Angle: Pi / 4}
+	corners := rotated.GetCorners()
+	if !rotated.IntersectsSegment(NewSegment(corners[0], corners[1])) {
+		t.Error("rotated: segment along an edge = false, want true")
+	}
+	if rotated.IntersectsSegment(NewSegment(V(-3, -1), V(-3, 11))) {
+		t.Error("rotated: segment left of the rotated corner = true, want false")
+	}
+}
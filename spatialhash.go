@@ -0,0 +1,76 @@
+package ebimath
+
+import "math"
+
+// SpatialHash buckets items into a uniform grid of square cells for broad-phase
+// collision queries. Each item is stored in the single cell containing its position.
+type SpatialHash[T any] struct {
+	cellSize float64
+	cells    map[Point][]T
+}
+
+// NewSpatialHash creates an empty spatial hash with the given cell size.
+// A non-positive cell size falls back to 1.
+func NewSpatialHash[T any](cellSize float64) *SpatialHash[T] {
+	if cellSize <= 0 {
+		cellSize = 1
+	}
+	return &SpatialHash[T]{
+		cellSize: cellSize,
+		cells:    make(map[Point][]T),
+	}
+}
+
+// CellSize returns the width and height of each cell.
+func (self *SpatialHash[T]) CellSize() float64 {
+	return self.cellSize
+}
+
+// Cell returns the grid cell containing the given position.
+func (self *SpatialHash[T]) Cell(pos Vector) Point {
+	// Floor rather than Pf, which truncates towards zero and would make the cells
+	// around the origin twice as large as the others.
+	return P(
+		int(math.Floor(pos.X/self.cellSize)),
+		int(math.Floor(pos.Y/self.cellSize)),
+	)
+}
+
+// Insert adds an item at the given position.
+func (self *SpatialHash[T]) Insert(pos Vector, item T) {
+	cell := self.Cell(pos)
+	self.cells[cell] = append(self.cells[cell], item)
+}
+
+// Query returns all items stored in cells overlapping the rectangle. This is a broad
+// phase: items near but outside the rectangle may be included. Rotated rectangles
+// are queried using their axis-aligned bounds. Items are returned in no particular order.
+func (self *SpatialHash[T]) Query(r Rectangle) []T {
+	if r.Angle != 0 {
+		r = r.RotatedBounds()
+	}
+	r = r.Normalized()
+	min, max := self.Cell(r.Min), self.Cell(r.Max)
+
+	var result []T
+	// For queries spanning more cells than are occupied, filter the occupied cells instead.
+	if span := float64(max.X-min.X+1) * float64(max.Y-min.Y+1); span > float64(len(self.cells)) {
+		for cell, items := range self.cells {
+			if min.X <= cell.X && cell.X <= max.X && min.Y <= cell.Y && cell.Y <= max.Y {
+				result = append(result, items...)
+			}
+		}
+		return result
+	}
+	for y := min.Y; y <= max.Y; y++ {
+		for x := min.X; x <= max.X; x++ {
+			result = append(result, self.cells[P(x, y)]...)
+		}
+	}
+	return result
+}
+
+// Clear removes all items while keeping the cell size.
+func (self *SpatialHash[T]) Clear() {
+	clear(self.cells)
+}
@@ -0,0 +1,76 @@
+package ebimath
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSpatialHashQuery(t *testing.T) {
+	h := NewSpatialHash[int](10)
+	// Items 0-4 cluster in one cell; 5-9 are spread far apart.
+	for i := 0; i < 5; i++ {
+		h.Insert(V(1+float64(i), 2), i)
+	}
+	for i := 5; i < 10; i++ {
+		h.Insert(V(float64(i)*100, float64(i)*-50), i)
+	}
+
+	got := h.Query(NewRectangle(0, 0, 9, 9))
+	slices.Sort(got)
+	if want := []int{0, 1, 2, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("clustered Query = %v, want %v", got, want)
+	}
+	if got := h.Query(NewRectangle(695, -355, 705, -345)); !slices.Equal(got, []int{7}) {
+		t.Errorf("spread Query = %v, want [7]", got)
+	}
+	if got := h.Query(NewRectangle(-1000, 1000, -900, 1100)); len(got) != 0 {
+		t.Errorf("empty area Query = %v, want none", got)
+	}
+
+	h.Clear()
+	if got := h.Query(NewRectangle(0, 0, 9, 9)); len(got) != 0 {
+		t.Errorf("Query after Clear = %v, want none", got)
+	}
+	if h.CellSize() != 10 {
+		t.Errorf("CellSize after Clear = %v, want 10", h.CellSize())
+	}
+}
+
+func TestSpatialHashNegativeCells(t *testing.T) {
+	h := NewSpatialHash[string](10)
+	h.Insert(V(5, 5), "positive")
+	h.Insert(V(-5, -5), "negative")
+
+	if got := h.Cell(V(-5, -5)); got != P(-1, -1) {
+		t.Errorf("Cell(-5, -5) = %v, want (-1, -1)", got)
+	}
+	if got := h.Query(NewRectangle(-6, -6, -4, -4)); !slices.Equal(got, []string{"negative"}) {
+		t.Errorf("Query around (-5, -5) = %v, want [negative]", got)
+	}
+}
+
+func TestSpatialHashLargeQuery(t *testing.T) {
+	h := NewSpatialHash[int](1)
+	h.Insert(V(2500.5, 2500.5), 1)
+	h.Insert(V(-10, 3), 2)
+	h.Insert(V(7000, 7000), 3)
+
+	got := h.Query(NewRectangle(0, 0, 6000, 6000))
+	if !slices.Equal(got, []int{1}) {
+		t.Errorf("large Query = %v, want [1]", got)
+	}
+	got = h.Query(NewRectangle(-20, -20, 8000, 8000))
+	slices.Sort(got)
+	if want := []int{1, 2, 3}; !slices.Equal(got, want) {
+		t.Errorf("covering Query = %v, want %v", got, want)
+	}
+}
+
+func BenchmarkSpatialHashLargeQuery(b *testing.B) {
+	h := NewSpatialHash[int](1)
+	h.Insert(V(2500, 2500), 1)
+	r := NewRectangle(0, 0, 6000, 6000)
+	for i := 0; i < b.N; i++ {
+		h.Query(r)
+	}
+}
@@ -1,5 +1,7 @@
 package ebimath
 
+import "github.com/hajimehoshi/ebiten/v2"
+
 // Transformer defines the interface for objects that have transforms.
 type Transformer interface {
 	GetParentTransform() *Transform
@@ -10,10 +12,15 @@ type Transformer interface {
 // It includes position, scale, rotation, and a parent for hierarchical transforms.
 type Transform struct {
 	position, scale, offset, origin Vector
+	shear                           Vector
 	rotation                        float64
 	parent                          *Transform
+	children                        []*Transform
 	isDirty                         bool // A single dirty flag for performance caching.
+	onDirty                         func()
 	worldMatrix                     Matrix
+	worldInverted                   Matrix
+	worldInvertedValid              bool // Whether worldInverted matches worldMatrix.
 	parentMatrix                    Matrix
 	parentInverted                  Matrix
 }
@@ -43,6 +50,34 @@ func (self *Transform) GetInitialParentTransform() *Transform {
 	return self
 }
 
+// Root returns the topmost transform in the hierarchy, or this transform if it has no parent.
+// It is equivalent to GetInitialParentTransform.
+func (self *Transform) Root() *Transform {
+	return self.GetInitialParentTransform()
+}
+
+// Depth returns the number of parents above this transform; a root has a depth of 0.
+func (self *Transform) Depth() int {
+	depth := 0
+	for p := self.parent; p != nil; p = p.parent {
+		depth++
+	}
+	return depth
+}
+
+// IsAncestorOf checks if this transform is a parent, grandparent, or further ancestor of other.
+func (self *Transform) IsAncestorOf(other *Transform) bool {
+	if other == nil {
+		return false
+	}
+	for p := other.parent; p != nil; p = p.parent {
+		if p == self {
+			return true
+		}
+	}
+	return false
+}
+
 // GetTransform returns this Transform.
 // This method fulfills the Transformer interface.
 func (self *Transform) GetTransform() *Transform {
@@ -58,8 +93,8 @@ func (self *Transform) Origin() Vector {
 
 // SetOrigin updates the transform's origin and marks it as dirty.
 func (self *Transform) SetOrigin(origin Vector) {
-	self.isDirty = true
 	self.origin = origin
+	self.markDirty()
 }
 
 // IsDirty checks if this transform or any of its parents are dirty.
@@ -75,26 +110,66 @@ func (self *Transform) IsDirty() bool {
 	return false
 }
 
+// OnDirty registers a callback invoked when the transform goes from clean to dirty.
+// Repeated changes before the next Matrix() call do not invoke it again.
+// The callback runs synchronously after the change has been applied, so it may read
+// the transform, and the transforms below it, safely. Passing nil removes the callback.
+func (self *Transform) OnDirty(fn func()) {
+	self.onDirty = fn
+}
+
+// markDirty flags the transform and its descendants for recomputation, notifying
+// dirty callbacks on a clean to dirty transition. Children must be flagged too,
+// otherwise recomputing only the parent would leave their cached matrices stale.
+// A clean transform always has clean ancestors, so stopping at an already dirty
+// node never skips a clean descendant.
+func (self *Transform) markDirty() {
+	if self.isDirty {
+		return
+	}
+	self.isDirty = true
+	for _, child := range self.children {
+		child.markDirty()
+	}
+	if self.onDirty != nil {
+		self.onDirty()
+	}
+}
+
 // Position and Movement
 // ---------------------
 // SetPosition updates the position, preserving the world-space position
 // by adjusting the local position based on the parent's inverse matrix.
 func (self *Transform) SetPosition(position Vector) {
-	self.isDirty = true
 	if self.parent != nil {
-		// Calculate the local position by transforming the world position by the parent's inverse matrix.
-		worldToLocalMatrix := self.parent.Matrix()
-		worldToLocalMatrix.Invert()
-		self.position = position.Apply(worldToLocalMatrix)
+		// Calculate the local position by transforming the world position by the parent's
+		// inverse matrix, which is cached and only recomputed when the parent is dirty.
+		self.position = position.Apply(self.parent.inverseMatrix())
 	} else {
 		// If there is no parent, the local position is the world position.
 		self.position = position
 	}
+	self.markDirty()
 }
 
 // Position returns the absolute position in world space.
-// It calculates the world position by applying the transform's world matrix to the zero vector.
+// It calculates the world position by applying the parent's world matrix to the local position.
 func (self *Transform) Position() Vector {
+	if self.parent == nil {
+		return self.position
+	}
+	return self.position.Apply(self.parent.Matrix())
+}
+
+// SetLocalPosition updates the position relative to the parent directly,
+// without converting from world space.
+func (self *Transform) SetLocalPosition(position Vector) {
+	self.position = position
+	self.markDirty()
+}
+
+// LocalPosition returns the position relative to the parent.
+func (self *Transform) LocalPosition() Vector {
 	return self.position
 }
 
@@ -108,7 +183,6 @@ func (self *Transform) Move(v ...Vector) {
 // SetRotation updates the rotation, preserving the world-space rotation
 // by adjusting the local rotation based on the parent's rotation.
 func (self *Transform) SetRotation(rotation float64) {
-	self.isDirty = true
 	if self.parent != nil {
 		// Calculate the local rotation by subtracting the parent's world rotation.
 		self.rotation = rotation - self.parent.Rotation()
@@ -116,6 +190,7 @@ func (self *Transform) SetRotation(rotation float64) {
 		// If there is no parent, the local rotation is the world rotation.
 		self.rotation = rotation
 	}
+	self.markDirty()
 }
 
 // Rotation returns the absolute rotation in world space.
@@ -126,18 +201,51 @@ func (self *Transform) Rotation() float64 {
 	return self.rotation + self.parent.Rotation()
 }
 
+// SetLocalRotation updates the rotation relative to the parent directly.
+func (self *Transform) SetLocalRotation(rotation float64) {
+	self.rotation = rotation
+	self.markDirty()
+}
+
+// LocalRotation returns the rotation relative to the parent.
+func (self *Transform) LocalRotation() float64 {
+	return self.rotation
+}
+
 // Rotate adds to the current rotation.
 func (self *Transform) Rotate(rotation float64) {
-	self.isDirty = true
 	self.rotation += rotation
+	self.markDirty()
+}
+
+// LookAt sets the world rotation so the transform's local +X axis points at target.
+func (self *Transform) LookAt(target Vector) {
+	self.LookAtOffset(target, 0)
+}
+
+// LookAtOffset works like LookAt for sprites that do not face +X by default.
+// offset is the local facing angle in radians (e.g. Pi/2 for sprites drawn facing +Y).
+func (self *Transform) LookAtOffset(target Vector, offset float64) {
+	self.SetRotation(self.Position().AngleToPoint(target) - offset)
+}
+
+// Forward returns the world-space unit vector along the transform's local +X axis.
+func (self *Transform) Forward() Vector {
+	return AngleToVector(self.Rotation(), 1)
+}
+
+// Right returns the world-space unit vector perpendicular to Forward, pointing to
+// the transform's right in screen coordinates (where +Y points down).
+func (self *Transform) Right() Vector {
+	return self.Forward().Rotate90()
 }
 
 // Scale
 // -----
 // SetScale updates the local scale.
 func (self *Transform) SetScale(scale Vector) {
-	self.isDirty = true
 	self.scale = scale
+	self.markDirty()
 }
 
 // Scale returns the absolute scale in world space by multiplying with the parent's scale.
@@ -148,18 +256,62 @@ func (self *Transform) Scale() Vector {
 	return self.scale.Scale(self.parent.Scale())
 }
 
+// SetLocalScale updates the local scale. It is equivalent to SetScale.
+func (self *Transform) SetLocalScale(scale Vector) {
+	self.SetScale(scale)
+}
+
+// LocalScale returns the scale relative to the parent.
+func (self *Transform) LocalScale() Vector {
+	return self.scale
+}
+
 // AddScale adds to the current local scale.
 func (self *Transform) AddScale(add ...Vector) {
-	self.isDirty = true
 	self.scale = self.scale.Add(add...)
+	self.markDirty()
+}
+
+// FlipX mirrors the transform horizontally by negating the local X scale.
+func (self *Transform) FlipX() {
+	self.SetScale(V(-self.scale.X, self.scale.Y))
+}
+
+// FlipY mirrors the transform vertically by negating the local Y scale.
+func (self *Transform) FlipY() {
+	self.SetScale(V(self.scale.X, -self.scale.Y))
+}
+
+// SetFlipX sets whether the transform is mirrored horizontally.
+func (self *Transform) SetFlipX(flip bool) {
+	if self.IsFlippedX() != flip {
+		self.FlipX()
+	}
+}
+
+// SetFlipY sets whether the transform is mirrored vertically.
+func (self *Transform) SetFlipY(flip bool) {
+	if self.IsFlippedY() != flip {
+		self.FlipY()
+	}
+}
+
+// IsFlippedX checks if the local X scale is negative.
+func (self *Transform) IsFlippedX() bool {
+	return self.scale.X < 0
+}
+
+// IsFlippedY checks if the local Y scale is negative.
+func (self *Transform) IsFlippedY() bool {
+	return self.scale.Y < 0
 }
 
 // Offset
 // ------
 // SetOffset updates the local offset.
 func (self *Transform) SetOffset(offset Vector) {
-	self.isDirty = true
 	self.offset = offset
+	self.markDirty()
 }
 
 // Offset returns the local offset.
@@ -167,6 +319,20 @@ func (self *Transform) Offset() Vector {
 	return self.offset
 }
 
+// Shear
+// -----
+// SetShear updates the local shear factors, applied between scale and rotation.
+// A shear of (x, y) maps a local point (px, py) to (px + x*py, py + y*px).
+func (self *Transform) SetShear(shear Vector) {
+	self.shear = shear
+	self.markDirty()
+}
+
+// Shear returns the local shear factors.
+func (self *Transform) Shear() Vector {
+	return self.shear
+}
+
 // Transform Modifiers
 // -------------------
 // Abs returns a new transform with the absolute world properties of this transform,
@@ -178,16 +344,67 @@ func (self *Transform) Abs() Transform {
 	abs.SetScale(self.Scale())
 	abs.SetOffset(self.Offset())
 	abs.SetOrigin(self.Origin())
+	abs.SetShear(self.Shear())
 	return abs
 }
 
-// Rel returns a copy of the transform with its parent set to nil.
+// Rel returns a copy of the transform with its parent set to nil, keeping its local
+// values. Like Clone, the copy has no children or dirty callback and is marked dirty,
+// since its cached matrices were computed under the parent.
 func (self *Transform) Rel() Transform {
 	rel := *self
 	rel.parent = nil
+	rel.children = nil
+	rel.onDirty = nil
+	rel.isDirty = true
 	return rel
 }
 
+// LerpTransform interpolates between the world properties of two transforms,
+// returning a parentless transform suitable for rendering between fixed updates.
+// Rotation is interpolated along the shortest path.
+func LerpTransform(a, b *Transform, t float64) Transform {
+	result := *T()
+	result.SetPosition(a.Position().Lerp(b.Position(), t))
+	result.SetRotation(LerpAngle(a.Rotation(), b.Rotation(), t))
+	result.SetScale(a.Scale().Lerp(b.Scale(), t))
+	result.SetOffset(a.Offset().Lerp(b.Offset(), t))
+	result.SetOrigin(a.Origin().Lerp(b.Origin(), t))
+	result.SetShear(a.Shear().Lerp(b.Shear(), t))
+	return result
+}
+
+// Clone returns an independent copy of the transform with identical local values
+// and the same parent. The copy starts without children or a dirty callback and
+// is marked dirty so its caches are recomputed. The parent keeps a reference to the
+// copy, so call Disconnect on it once it is no longer used to let it be released.
+func (self *Transform) Clone() *Transform {
+	clone := *self
+	clone.isDirty = true
+	clone.children = nil
+	clone.onDirty = nil
+	if clone.parent != nil {
+		clone.parent.addChild(&clone)
+	}
+	return &clone
+}
+
+// Reset restores the transform to its default state: zero position, offset, origin, and shear,
+// no rotation, a scale of 1, and no parent. Its children and dirty callback are kept.
+func (self *Transform) Reset() {
+	if self.parent != nil {
+		self.parent.removeChild(self)
+		self.parent = nil
+	}
+	self.position = ZeroVector
+	self.offset = ZeroVector
+	self.origin = ZeroVector
+	self.shear = ZeroVector
+	self.rotation = 0
+	self.scale = V2(1)
+	self.markDirty()
+}
+
 // Parent Management
 // -----------------
 // Connected returns true if the transform has a parent.
@@ -204,41 +421,91 @@ func (self *Transform) Replace(new Transformer) {
 	self.SetRotation(nt.Rotation())
 	self.SetOrigin(nt.Origin())
 	self.SetScale(nt.Scale())
+	self.SetShear(nt.Shear())
 }
 
 // Connect establishes a parent-child relationship, preserving the object's
-// world space transform.
+// world space transform. Connecting to itself or to one of its own descendants
+// would create a cycle and is ignored. The parent keeps a reference to this transform
+// and updates it on every change, so call Disconnect before discarding it.
 func (self *Transform) Connect(parent Transformer) {
 	if parent == nil {
 		return
 	}
+	// A typed nil, such as the result of GetParentTransform on a root, is ignored too.
+	p := parent.GetTransform()
+	if p == nil || p == self || self.IsAncestorOf(p) {
+		return
+	}
 	// Store the current world properties before connecting to the parent.
 	worldPos := self.Position()
 	worldRot := self.Rotation()
 	worldScale := self.Scale()
-	worldOffset := self.Offset()
-	worldOrigin := self.Origin()
-
-	// Set the new parent and mark the transform as dirty.
-	self.parent = parent.GetTransform()
-	self.isDirty = true
 
-	// Re-apply the stored world properties, which will internally
-	// calculate the new local properties based on the new parent.
-	self.SetPosition(worldPos)
-	self.SetRotation(worldRot)
-	self.SetScale(worldScale)
-	self.SetOffset(worldOffset)
-	self.SetOrigin(worldOrigin)
+	// Detach from any previous parent, then set the new parent.
+	if self.parent != nil {
+		self.parent.removeChild(self)
+	}
+	self.parent = p
+	self.parent.addChild(self)
+
+	// Convert the stored world properties to local properties under the new parent,
+	// as SetPosition, SetRotation, and SetScale do, then mark the transform as dirty
+	// once everything has been applied.
+	self.position = worldPos.Apply(self.parent.inverseMatrix())
+	self.rotation = worldRot - self.parent.Rotation()
+	self.scale = worldScale
+	self.markDirty()
 }
 
 // Disconnect removes the parent relationship, making the transform absolute.
 // It does this by creating a new absolute transform and overwriting the current one.
+// The transform's own children and dirty callback are kept.
 func (self *Transform) Disconnect() {
 	if self.parent == nil {
 		return
 	}
+	self.parent.removeChild(self)
+	wasDirty := self.isDirty
+	children, onDirty := self.children, self.onDirty
 	*self = self.Abs()
+	self.children, self.onDirty = children, onDirty
+	// Restore the previous dirty state so marking it dirty now flags the children
+	// and notifies the callback once the overwrite is complete.
+	self.isDirty = wasDirty
+	self.markDirty()
+}
+
+// Children returns the transforms directly connected to this one.
+// The returned slice must not be modified, but it stays valid while children are
+// disconnected, so it is safe to Disconnect each child while ranging over it.
+// Children stay registered, and are not garbage collected, until they call
+// Disconnect or are reconnected elsewhere.
+func (self *Transform) Children() []*Transform {
+	return self.children
+}
+
+// addChild registers a child transform, ignoring duplicates.
+func (self *Transform) addChild(child *Transform) {
+	for _, c := range self.children {
+		if c == child {
+			return
+		}
+	}
+	self.children = append(self.children, child)
+}
+
+// removeChild unregisters a child transform if present. It builds a new slice
+// rather than compacting in place, so slices returned by Children are unaffected.
+func (self *Transform) removeChild(child *Transform) {
+	for i, c := range self.children {
+		if c == child {
+			children := make([]*Transform, 0, len(self.children)-1)
+			children = append(children, self.children[:i]...)
+			self.children = append(children, self.children[i+1:]...)
+			return
+		}
+	}
 }
 
 // Matrix Operations
@@ -263,11 +530,17 @@ func (self *Transform) Matrix() Matrix {
 	// Calculate the local transformation matrix.
 	localMatrix := Matrix{} // Scale first.
 	localMatrix.Scale(self.scale.X, self.scale.Y)
-	// Then move and rotate.
+	// Then move, shear, and rotate.
 	localMatrix.Translate(
 		-self.offset.X*self.scale.X,
 		-self.offset.Y*self.scale.Y,
 	)
+	if !self.shear.IsZero() {
+		shear := Matrix{}
+		shear.SetElement(0, 1, self.shear.X)
+		shear.SetElement(1, 0, self.shear.Y)
+		localMatrix.Concat(shear)
+	}
 	localMatrix.Rotate(float64(self.rotation))
 	// Move to the absolute position.
 	localMatrix.Translate(self.position.X, self.position.Y)
@@ -287,6 +560,61 @@ func (self *Transform) Matrix() Matrix {
 	}
 
 	self.worldMatrix = localMatrix
+	self.worldInvertedValid = false
 	self.isDirty = false
 	return self.worldMatrix
 }
+
+// inverseMatrix returns the cached inverse of the world matrix. The inverse is only
+// computed on demand, so transforms that are never inverted, such as most leaves,
+// do not pay for it when their world matrix changes.
+func (self *Transform) inverseMatrix() Matrix {
+	self.Matrix()
+	if !self.worldInvertedValid {
+		self.worldInverted = self.worldMatrix
+		self.worldInverted.Invert()
+		self.worldInvertedValid = true
+	}
+	return self.worldInverted
+}
+
+// Point Conversion
+// ----------------
+// TransformPoint converts a point from this transform's local space to world space.
+func (self *Transform) TransformPoint(local Vector) Vector {
+	return local.Apply(self.Matrix())
+}
+
+// InverseTransformPoint converts a point from world space to this transform's local space.
+func (self *Transform) InverseTransformPoint(world Vector) Vector {
+	return world.Apply(self.inverseMatrix())
+}
+
+// TransformDirection converts a direction from local space to world space,
+// applying rotation and scale but ignoring translation.
+func (self *Transform) TransformDirection(dir Vector) Vector {
+	m := self.Matrix()
+	return dir.Apply(m).Sub(ZeroVector.Apply(m))
+}
+
+// InverseTransformDirection converts a direction from world space to local space,
+// applying the inverse rotation and scale but ignoring translation.
+func (self *Transform) InverseTransformDirection(dir Vector) Vector {
+	m := self.inverseMatrix()
+	return dir.Apply(m).Sub(ZeroVector.Apply(m))
+}
+
+// TransformBounds returns the world-space axis-aligned bounding box of a rectangle
+// defined in this transform's local space. Rotation widens the box as needed.
+func (self *Transform) TransformBounds(local Rectangle) Rectangle {
+	corners := TransformRectangle(self.Matrix(), local)
+	return BoundingBox(corners[:]...)
+}
+
+// DrawImageOptions returns draw options with GeoM set to the world matrix,
+// including the same origin and offset handling as Matrix().
+func (self *Transform) DrawImageOptions() *ebiten.DrawImageOptions {
+	opts := &ebiten.DrawImageOptions{}
+	opts.GeoM = self.Matrix()
+	return opts
+}
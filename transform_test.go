@@ -0,0 +1,533 @@
+package ebimath
+
+import "testing"
+
+// newTestHierarchy returns a rotated, scaled parent and a child connected to it.
+func newTestHierarchy() (parent, child *Transform) {
+	parent = T()
+	parent.SetPosition(V(100, 50))
+	parent.SetRotation(Pi / 3)
+	parent.SetScale(V(2, 0.5))
+	child = T()
+	child.SetPosition(V(120, 40))
+	child.SetRotation(0.4)
+	child.Connect(parent)
+	return parent, child
+}
+
+func TestTransformPointRoundTrip(t *testing.T) {
+	_, child := newTestHierarchy()
+	for _, local := range []Vector{V(0, 0), V(3, -7), V(-12.5, 4)} {
+		world := child.TransformPoint(local)
+		if got := child.InverseTransformPoint(world); !vectorApproxEqual(got, local) {
+			t.Errorf("InverseTransformPoint(TransformPoint(%v)) = %v", local, got)
+		}
+	}
+	if got := child.TransformPoint(V(1, 1)); !vectorApproxEqual(got, V(1, 1).Apply(child.Matrix())) {
+		t.Errorf("TransformPoint = %v, want the world matrix applied", got)
+	}
+}
+
+func TestTransformDirectionIgnoresTranslation(t *testing.T) {
+	tr := T()
+	tr.SetRotation(Pi / 2)
+	tr.SetScale(V(2, 2))
+	dir := V(1, 0)
+	before := tr.TransformDirection(dir)
+	if !vectorApproxEqual(before, V(0, 2)) {
+		t.Errorf("TransformDirection = %v, want %v", before, V(0, 2))
+	}
+
+	tr.Move(V(300, -40))
+	if after := tr.TransformDirection(dir); !vectorApproxEqual(after, before) {
+		t.Errorf("TransformDirection after moving = %v, want %v", after, before)
+	}
+	if got := tr.InverseTransformDirection(before); !vectorApproxEqual(got, dir) {
+		t.Errorf("InverseTransformDirection = %v, want %v", got, dir)
+	}
+}
+
+func TestTransformLookAt(t *testing.T) {
+	tr := T()
+	// Upper-right on screen, where +Y points down.
+	tr.LookAt(V(10, -10))
+	if got := tr.Rotation(); !approxEqual(got, -Pi/4) {
+		t.Errorf("LookAt rotation = %v, want %v", got, -Pi/4)
+	}
+	if got := tr.Forward(); !vectorApproxEqual(got, V(1, -1).Normalize()) {
+		t.Errorf("Forward after LookAt = %v, want towards the target", got)
+	}
+
+	tr.LookAtOffset(V(10, -10), Pi/2)
+	if got := tr.Rotation(); !approxEqual(got, -3*Pi/4) {
+		t.Errorf("LookAtOffset rotation = %v, want %v", got, -3*Pi/4)
+	}
+}
+
+func TestTransformCloneIsIndependent(t *testing.T) {
+	parent, original := newTestHierarchy()
+	originalMatrix := original.Matrix()
+
+	clone := original.Clone()
+	if clone.GetParentTransform() != parent {
+		t.Fatal("Clone did not keep the parent")
+	}
+	if !matrixApproxEqual(clone.Matrix(), originalMatrix) {
+		t.Errorf("clone matrix = %v, want %v", clone.Matrix(), originalMatrix)
+	}
+
+	clone.Move(V(10, 10))
+	clone.Rotate(1)
+	clone.SetScale(V(3, 3))
+	if !matrixApproxEqual(original.Matrix(), originalMatrix) {
+		t.Errorf("original matrix changed to %v after mutating the clone", original.Matrix())
+	}
+}
+
+func TestLerpTransform(t *testing.T) {
+	a, b := T(), T()
+	a.SetPosition(V(0, 0))
+	a.SetRotation(ToRadians(170))
+	a.SetScale(V(1, 1))
+	b.SetPosition(V(10, -20))
+	b.SetRotation(ToRadians(-170))
+	b.SetScale(V(3, 2))
+
+	tests := []struct {
+		t        float64
+		position Vector
+		rotation float64
+		scale    Vector
+	}{
+		{0, V(0, 0), ToRadians(170), V(1, 1)},
+		{0.5, V(5, -10), ToRadians(180), V(2, 1.5)},
+		{1, V(10, -20), ToRadians(-170), V(3, 2)},
+	}
+	for _, tt := range tests {
+		got := LerpTransform(a, b, tt.t)
+		if got.Connected() {
+			t.Errorf("t=%v: LerpTransform result has a parent", tt.t)
+		}
+		if !vectorApproxEqual(got.Position(), tt.position) {
+			t.Errorf("t=%v: position = %v, want %v", tt.t, got.Position(), tt.position)
+		}
+		// The rotation takes the short way through 180° rather than passing 0°.
+		if !approxEqual(DeltaAngle(got.Rotation(), tt.rotation), 0) {
+			t.Errorf("t=%v: rotation = %v°, want %v°", tt.t, ToDegrees(got.Rotation()), ToDegrees(tt.rotation))
+		}
+		if !vectorApproxEqual(got.Scale(), tt.scale) {
+			t.Errorf("t=%v: scale = %v, want %v", tt.t, got.Scale(), tt.scale)
+		}
+	}
+}
+
+func TestTransformChildren(t *testing.T) {
+	a, b, child := T(), T(), T()
+
+	child.Connect(a)
+	child.Connect(a) // connecting twice must not duplicate
+	if got := a.Children(); len(got) != 1 || got[0] != child {
+		t.Fatalf("a.Children() = %v, want [child]", got)
+	}
+
+	child.Connect(b)
+	if got := a.Children(); len(got) != 0 {
+		t.Errorf("a.Children() after re-parenting = %v, want none", got)
+	}
+	if got := b.Children(); len(got) != 1 || got[0] != child {
+		t.Errorf("b.Children() after re-parenting = %v, want [child]", got)
+	}
+
+	child.Disconnect()
+	if got := b.Children(); len(got) != 0 {
+		t.Errorf("b.Children() after Disconnect = %v, want none", got)
+	}
+	if child.Connected() {
+		t.Error("child still connected after Disconnect")
+	}
+}
+
+func TestTransformOnDirtyFiresOncePerTransition(t *testing.T) {
+	tr := T()
+	tr.Matrix() // start clean
+	calls := 0
+	tr.OnDirty(func() { calls++ })
+
+	tr.SetPosition(V(1, 2))
+	tr.SetRotation(1)
+	tr.SetScale(V(2, 2))
+	if calls != 1 {
+		t.Fatalf("callback fired %d times for one dirty period, want 1", calls)
+	}
+
+	tr.Matrix()
+	tr.Move(V(1, 0))
+	if calls != 2 {
+		t.Errorf("callback fired %d times after a second transition, want 2", calls)
+	}
+
+	tr.OnDirty(nil)
+	tr.Matrix()
+	tr.Move(V(1, 0))
+	if calls != 2 {
+		t.Errorf("removed callback fired, calls = %d", calls)
+	}
+}
+
+func TestTransformOnDirtySeesAppliedChange(t *testing.T) {
+	tr := T()
+	tr.Matrix()
+	var seen Vector
+	// Reading the transform inside the callback must not leave a stale cache behind.
+	tr.OnDirty(func() { seen = tr.TransformPoint(ZeroVector) })
+
+	tr.SetPosition(V(10, 0))
+	if !vectorApproxEqual(seen, V(10, 0)) {
+		t.Errorf("callback saw origin at %v, want %v", seen, V(10, 0))
+	}
+	if got := tr.TransformPoint(ZeroVector); !vectorApproxEqual(got, V(10, 0)) {
+		t.Errorf("origin after callback = %v, want %v", got, V(10, 0))
+	}
+}
+
+func TestTransformOnDirtyFiresForParentChanges(t *testing.T) {
+	parent, child := newTestHierarchy()
+	child.Matrix()
+	var seen Vector
+	child.OnDirty(func() { seen = child.Position() })
+
+	parent.Move(V(5, 0))
+	if want := child.Position(); !vectorApproxEqual(seen, want) {
+		t.Errorf("child callback saw %v, want %v", seen, want)
+	}
+}
+
+func TestTransformFlip(t *testing.T) {
+	tr := T()
+	tr.SetPosition(V(10, 20))
+	p := V(3, 4)
+
+	tr.SetFlipX(true)
+	if !tr.IsFlippedX() || tr.IsFlippedY() {
+		t.Fatalf("IsFlippedX = %v, IsFlippedY = %v after SetFlipX(true)", tr.IsFlippedX(), tr.IsFlippedY())
+	}
+	if got, want := tr.TransformPoint(p), V(7, 24); !vectorApproxEqual(got, want) {
+		t.Errorf("flipped X maps %v to %v, want %v", p, got, want)
+	}
+	tr.SetFlipX(true) // already flipped, no change
+	if !tr.IsFlippedX() {
+		t.Error("SetFlipX(true) twice unflipped the transform")
+	}
+
+	tr.FlipY()
+	if got, want := tr.TransformPoint(p), V(7, 16); !vectorApproxEqual(got, want) {
+		t.Errorf("flipped XY maps %v to %v, want %v", p, got, want)
+	}
+
+	tr.SetFlipX(false)
+	tr.SetFlipY(false)
+	if got, want := tr.TransformPoint(p), V(13, 24); !vectorApproxEqual(got, want) {
+		t.Errorf("unflipped maps %v to %v, want %v", p, got, want)
+	}
+}
+
+func TestTransformReset(t *testing.T) {
+	parent, child := newTestHierarchy()
+	child.SetOffset(V(3, 4))
+	child.SetOrigin(V(1, 1))
+	child.SetShear(V(0.5, 0))
+
+	child.Reset()
+	if child.Connected() {
+		t.Error("Reset left the transform connected")
+	}
+	if len(parent.Children()) != 0 {
+		t.Errorf("parent still has %d children after Reset", len(parent.Children()))
+	}
+	if !matrixApproxEqual(child.Matrix(), Matrix{}) {
+		t.Errorf("Reset matrix = %v, want identity", child.Matrix())
+	}
+
+	parent.Move(V(50, 50))
+	if !matrixApproxEqual(child.Matrix(), Matrix{}) {
+		t.Errorf("moving the old parent changed the reset child: %v", child.Matrix())
+	}
+}
+
+func TestTransformBounds(t *testing.T) {
+	tr := T()
+	tr.SetPosition(V(10, -5))
+	tr.SetRotation(Pi / 6)
+	tr.SetScale(V(2, 3))
+	local := NewRectangle(-1, -2, 4, 1)
+
+	m := tr.Matrix()
+	corners := []Vector{
+		local.Min.Apply(m),
+		V(local.Max.X, local.Min.Y).Apply(m),
+		local.Max.Apply(m),
+		V(local.Min.X, local.Max.Y).Apply(m),
+	}
+	want := BoundingBox(corners...)
+	got := tr.TransformBounds(local)
+	if !vectorApproxEqual(got.Min, want.Min) || !vectorApproxEqual(got.Max, want.Max) {
+		t.Errorf("TransformBounds = %v, want %v", got, want)
+	}
+}
+
+func TestTransformForwardRight(t *testing.T) {
+	_, child := newTestHierarchy()
+	fwd, right := child.Forward(), child.Right()
+	if !approxEqual(fwd.Length(), 1) || !approxEqual(right.Length(), 1) {
+		t.Errorf("lengths = %v, %v, want 1", fwd.Length(), right.Length())
+	}
+	if !approxEqual(fwd.Dot(right), 0) {
+		t.Errorf("Forward·Right = %v, want 0", fwd.Dot(right))
+	}
+	if want := AngleToVector(child.Rotation(), 1); !vectorApproxEqual(fwd, want) {
+		t.Errorf("Forward = %v, want %v including the parent's rotation", fwd, want)
+	}
+
+	tr := T()
+	if !vectorApproxEqual(tr.Forward(), V(1, 0)) || !vectorApproxEqual(tr.Right(), V(0, 1)) {
+		t.Errorf("identity Forward, Right = %v, %v, want (1,0), (0,1)", tr.Forward(), tr.Right())
+	}
+}
+
+func TestTransformDrawImageOptions(t *testing.T) {
+	_, child := newTestHierarchy()
+	child.SetOrigin(V(8, 8))
+	child.SetOffset(V(2, 0))
+	if got, want := child.DrawImageOptions().GeoM, child.Matrix(); got != want {
+		t.Errorf("GeoM = %v, want %v", got, want)
+	}
+}
+
+func TestTransformInverseStaysInSync(t *testing.T) {
+	parent, child := newTestHierarchy()
+	p := V(7, -2)
+	if got := child.InverseTransformPoint(child.TransformPoint(p)); !vectorApproxEqual(got, p) {
+		t.Fatalf("round trip = %v, want %v", got, p)
+	}
+
+	// The cached inverse must be dropped whenever the world matrix changes.
+	parent.Move(V(30, 0))
+	parent.Rotate(0.5)
+	if got := child.InverseTransformPoint(child.TransformPoint(p)); !vectorApproxEqual(got, p) {
+		t.Errorf("round trip after parent change = %v, want %v", got, p)
+	}
+	world := child.Position()
+	want := child.Matrix()
+	want.Invert()
+	if got := world.Apply(want); !vectorApproxEqual(got, child.InverseTransformPoint(world)) {
+		t.Errorf("InverseTransformPoint disagrees with an inverted Matrix: %v", got)
+	}
+}
+
+func BenchmarkTransformMoveChildren(b *testing.B) {
+	// The parent never changes, so every child's SetPosition reuses its cached inverse.
+	parent, _ := newTestHierarchy()
+	children := make([]*Transform, 1000)
+	for i := range children {
+		children[i] = T()
+		children[i].SetPosition(V(float64(i), 0))
+		children[i].Connect(parent)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, child := range children {
+			child.Move(V(1, 0))
+		}
+	}
+}
+
+func TestTransformLocalVsWorldPosition(t *testing.T) {
+	parent := T()
+	parent.SetPosition(V(10, 0))
+	parent.SetRotation(Pi / 2)
+	child := T()
+	child.Connect(parent)
+
+	child.SetLocalPosition(V(5, 0))
+	if got := child.LocalPosition(); got != V(5, 0) {
+		t.Errorf("LocalPosition = %v, want %v", got, V(5, 0))
+	}
+	// A quarter turn maps the parent's +X axis onto world +Y.
+	if got, want := child.Position(), V(10, 5); !vectorApproxEqual(got, want) {
+		t.Errorf("Position after SetLocalPosition = %v, want %v", got, want)
+	}
+
+	child.SetPosition(V(10, 5))
+	if got, want := child.LocalPosition(), V(5, 0); !vectorApproxEqual(got, want) {
+		t.Errorf("LocalPosition after SetPosition = %v, want %v", got, want)
+	}
+	child.SetPosition(V(7, 0))
+	if got, want := child.LocalPosition(), V(0, 3); !vectorApproxEqual(got, want) {
+		t.Errorf("LocalPosition after SetPosition = %v, want %v", got, want)
+	}
+}
+
+func TestTransformLocalGetters(t *testing.T) {
+	parent, child := newTestHierarchy()
+	child.SetLocalRotation(0.25)
+	child.SetLocalScale(V(3, 4))
+
+	if got := child.LocalRotation(); got != 0.25 {
+		t.Errorf("LocalRotation = %v, want 0.25", got)
+	}
+	if got, want := child.Rotation(), 0.25+parent.Rotation(); !approxEqual(got, want) {
+		t.Errorf("Rotation = %v, want %v", got, want)
+	}
+	if got := child.LocalScale(); got != V(3, 4) {
+		t.Errorf("LocalScale = %v, want %v", got, V(3, 4))
+	}
+	if got, want := child.Scale(), V(3, 4).Scale(parent.Scale()); !vectorApproxEqual(got, want) {
+		t.Errorf("Scale = %v, want %v", got, want)
+	}
+
+	child.Disconnect()
+	if !approxEqual(child.LocalRotation(), child.Rotation()) || child.LocalScale() != child.Scale() {
+		t.Error("an unparented transform's local and world values differ")
+	}
+}
+
+func TestTransformShear(t *testing.T) {
+	tr := T()
+	tr.SetShear(V(0.5, 0))
+	tests := []struct{ local, want Vector }{
+		{V(0, 0), V(0, 0)},
+		{V(1, 0), V(1, 0)},
+		{V(1, 1), V(1.5, 1)},
+		{V(0, 1), V(0.5, 1)},
+	}
+	for _, tt := range tests {
+		if got := tr.TransformPoint(tt.local); !vectorApproxEqual(got, tt.want) {
+			t.Errorf("sheared %v = %v, want %v", tt.local, got, tt.want)
+		}
+	}
+
+	tr.SetShear(V(0, 0.25))
+	if got, want := tr.TransformPoint(V(2, 0)), V(2, 0.5); !vectorApproxEqual(got, want) {
+		t.Errorf("Y shear maps (2,0) to %v, want %v", got, want)
+	}
+	if got := tr.Shear(); got != V(0, 0.25) {
+		t.Errorf("Shear = %v, want %v", got, V(0, 0.25))
+	}
+}
+
+func TestTransformDepthAndCycles(t *testing.T) {
+	root, middle, leaf := T(), T(), T()
+	middle.Connect(root)
+	leaf.Connect(middle)
+
+	for _, tt := range []struct {
+		tr   *Transform
+		want int
+	}{{root, 0}, {middle, 1}, {leaf, 2}} {
+		if got := tt.tr.Depth(); got != tt.want {
+			t.Errorf("Depth = %d, want %d", got, tt.want)
+		}
+	}
+	if leaf.Root() != root {
+		t.Error("leaf.Root() is not the root")
+	}
+	if !root.IsAncestorOf(leaf) || leaf.IsAncestorOf(root) || root.IsAncestorOf(root) {
+		t.Error("IsAncestorOf gave the wrong answer")
+	}
+
+	// Connecting to a descendant or to itself would form a cycle and is ignored.
+	root.Connect(leaf)
+	if root.Connected() || root.Depth() != 0 {
+		t.Error("Connect to a descendant formed a cycle")
+	}
+	leaf.Connect(leaf)
+	if leaf.GetParentTransform() != middle {
+		t.Error("Connect to itself changed the parent")
+	}
+}
+
+func TestTransformChildFollowsParent(t *testing.T) {
+	parent, child := newTestHierarchy()
+	before := child.Position()
+	child.Matrix() // cache the child before touching the parent
+
+	parent.Move(V(25, -10))
+	if got, want := child.Position(), before.Add(V(25, -10)); !vectorApproxEqual(got, want) {
+		t.Errorf("child Position after parent move = %v, want %v", got, want)
+	}
+
+	// Recomputing only the parent must not leave the child's cached matrix stale.
+	parent.Rotate(0.3)
+	parent.Matrix()
+	if got, want := child.TransformPoint(ZeroVector), child.Position(); !vectorApproxEqual(got, want) {
+		t.Errorf("child matrix origin = %v, want its world position %v", got, want)
+	}
+	want := NewMatrix(child.LocalPosition(), child.LocalRotation(), child.LocalScale())
+	want.Concat(parent.Matrix())
+	if got := child.Matrix(); !matrixApproxEqual(got, want) {
+		t.Errorf("child matrix = %v, want local matrix times parent %v", got, want)
+	}
+}
+
+func TestTransformConnectTypedNil(t *testing.T) {
+	root, child := T(), T()
+	child.SetPosition(V(3, 4))
+	// root has no parent, so this passes a nil *Transform inside a non-nil Transformer.
+	child.Connect(root.GetParentTransform())
+	if child.Connected() {
+		t.Error("Connect to a typed nil parent connected the transform")
+	}
+	if got := child.Position(); got != V(3, 4) {
+		t.Errorf("Position after typed nil Connect = %v, want %v", got, V(3, 4))
+	}
+}
+
+func TestTransformDisconnectWhileRangingChildren(t *testing.T) {
+	parent := T()
+	for i := 0; i < 4; i++ {
+		T().Connect(parent)
+	}
+	seen := map[*Transform]bool{}
+	for _, c := range parent.Children() {
+		if seen[c] {
+			t.Fatalf("Children yielded %p twice", c)
+		}
+		seen[c] = true
+		c.Disconnect()
+	}
+	if len(seen) != 4 {
+		t.Errorf("visited %d children, want 4", len(seen))
+	}
+	if got := parent.Children(); len(got) != 0 {
+		t.Errorf("%d children still attached after disconnecting all", len(got))
+	}
+	for c := range seen {
+		if c.Connected() {
+			t.Error("a child is still connected")
+		}
+	}
+}
+
+func TestTransformRelDropsParentCaches(t *testing.T) {
+	parent := T()
+	parent.SetPosition(V(100, 0))
+	child := T()
+	child.Connect(parent)
+	child.SetLocalPosition(V(5, 0))
+	child.Matrix() // cache the world matrix under the parent
+	calls := 0
+	child.OnDirty(func() { calls++ })
+
+	rel := child.Rel()
+	if got := rel.Position(); got != V(5, 0) {
+		t.Errorf("Rel Position = %v, want %v", got, V(5, 0))
+	}
+	if got := rel.TransformPoint(ZeroVector); !vectorApproxEqual(got, V(5, 0)) {
+		t.Errorf("Rel TransformPoint(origin) = %v, want %v", got, V(5, 0))
+	}
+	rel.Matrix()
+	rel.Move(V(1, 0))
+	if calls != 0 {
+		t.Errorf("changing the Rel copy fired the original's callback %d times", calls)
+	}
+}
@@ -2,6 +2,7 @@ package ebimath
 
 import (
 	"math"
+	"math/bits"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
@@ -50,6 +51,30 @@ func Repeat(t, length float64) float64 {
 	return Clamp(t-math.Floor(t/length)*length, 0, length)
 }
 
+// PingPong bounces a value back and forth between 0 and length as a triangle wave.
+// Returns 0 if length is not positive.
+func PingPong(t, length float64) float64 {
+	if length <= 0 {
+		return 0
+	}
+	t = Repeat(t, length*2)
+	return length - math.Abs(t-length)
+}
+
+// WrapInt wraps an integer into the half-open range [min, max).
+// Returns min if the range is empty.
+func WrapInt(value, min, max int) int {
+	size := max - min
+	if size <= 0 {
+		return min
+	}
+	offset := (value - min) % size
+	if offset < 0 {
+		offset += size
+	}
+	return min + offset
+}
+
 // CubicInterpolate performs cubic interpolation between values.
 func CubicInterpolate(from, to, pre, post, t float64) float64 {
 	return 0.5 *
@@ -71,6 +96,31 @@ func ToRadians(degrees float64) float64 {
 	return degrees * degToRad
 }
 
+// DeltaAngle returns the shortest signed difference between two angles in radians,
+// in the range [-Pi, Pi].
+func DeltaAngle(from, to float64) float64 {
+	delta := Repeat(to-from, 2*Pi)
+	if delta > Pi {
+		delta -= 2 * Pi
+	}
+	return delta
+}
+
+// LerpAngle interpolates between two angles in radians along the shortest path.
+func LerpAngle(from, to, t float64) float64 {
+	return from + DeltaAngle(from, to)*t
+}
+
+// MoveTowardsAngle rotates current towards target by at most maxDelta radians
+// along the shortest path, snapping to target when within range.
+func MoveTowardsAngle(current, target, maxDelta float64) float64 {
+	delta := DeltaAngle(current, target)
+	if math.Abs(delta) <= maxDelta {
+		return target
+	}
+	return current + math.Copysign(maxDelta, delta)
+}
+
 // Linear Interpolation
 // --------------------
 // Lerp performs linear interpolation.
@@ -78,6 +128,99 @@ func Lerp[T Number](from, to, t T) T {
 	return from + ((to - from) * t)
 }
 
+// InverseLerp returns where value falls between from and to as a fraction.
+// Returns 0 if from and to are equal.
+func InverseLerp(from, to, value float64) float64 {
+	if from == to {
+		return 0
+	}
+	return (value - from) / (to - from)
+}
+
+// Remap converts a value from the input range to the output range.
+func Remap(value, inMin, inMax, outMin, outMax float64) float64 {
+	return Lerp(outMin, outMax, InverseLerp(inMin, inMax, value))
+}
+
+// MapRange is the generic counterpart of Remap for any float type, such as float32
+// shader inputs. Reversed ranges are supported. Returns outMin if inMin equals inMax.
+func MapRange[T Float](value, inMin, inMax, outMin, outMax T) T {
+	if inMin == inMax {
+		return outMin
+	}
+	return outMin + (value-inMin)*(outMax-outMin)/(inMax-inMin)
+}
+
+// SmoothStep returns a smooth Hermite interpolation between 0 and 1 as value goes
+// from edge0 to edge1, with zero slope at both edges. Values outside are clamped.
+func SmoothStep(edge0, edge1, value float64) float64 {
+	t := Clamp01(InverseLerp(edge0, edge1, value))
+	return t * t * (3 - 2*t)
+}
+
+// Approach moves current towards target by at most delta without overshooting.
+// It is the scalar counterpart of Vector.MoveTowards.
+func Approach(current, target, delta float64) float64 {
+	if math.Abs(target-current) <= delta {
+		return target
+	}
+	return current + math.Copysign(delta, target-current)
+}
+
+// Smoothing
+// ---------
+// SmoothDamp gradually moves current towards target using a critically damped spring,
+// easing in and out without overshooting. velocity holds the spring state between
+// calls and is updated in place. smoothTime is roughly the time to reach the target.
+func SmoothDamp(current, target float64, velocity *float64, smoothTime, dt float64) float64 {
+	smoothTime = math.Max(0.0001, smoothTime)
+	omega := 2 / smoothTime
+	// Approximation of exp(-omega*dt) that is stable for large steps.
+	x := omega * dt
+	decay := 1 / (1 + x + 0.48*x*x + 0.235*x*x*x)
+
+	change := current - target
+	temp := (*velocity + omega*change) * dt
+	*velocity = (*velocity - omega*temp) * decay
+	output := target + (change+temp)*decay
+
+	// Prevent overshooting the target.
+	if (target-current > 0) == (output > target) {
+		output = target
+		*velocity = 0
+	}
+	return output
+}
+
+// SmoothDampVector gradually moves current towards target using a critically damped
+// spring. It is the Vector counterpart of SmoothDamp, with the movement speed capped
+// at maxSpeed units per second. A non-positive maxSpeed disables the cap.
+func SmoothDampVector(current, target Vector, velocity *Vector, smoothTime, maxSpeed, dt float64) Vector {
+	smoothTime = math.Max(0.0001, smoothTime)
+	omega := 2 / smoothTime
+	x := omega * dt
+	decay := 1 / (1 + x + 0.48*x*x + 0.235*x*x*x)
+
+	change := current.Sub(target)
+	originalTarget := target
+	// Limiting the distance the spring can pull per smoothTime caps the speed.
+	if maxSpeed > 0 {
+		change = change.ClampLength(maxSpeed * smoothTime)
+		target = current.Sub(change)
+	}
+
+	temp := velocity.Add(change.ScaleF(omega)).ScaleF(dt)
+	*velocity = velocity.Sub(temp.ScaleF(omega)).ScaleF(decay)
+	output := target.Add(change.Add(temp).ScaleF(decay))
+
+	// Prevent overshooting the target.
+	if originalTarget.Sub(current).Dot(output.Sub(originalTarget)) > 0 {
+		output = originalTarget
+		*velocity = Vector{}
+	}
+	return output
+}
+
 // Clamping and Rounding
 // ---------------------
 // Clamp restricts a value to be within specified bounds.
@@ -91,6 +234,25 @@ func Clamp[T Number](value, min, max T) T {
 	return value
 }
 
+// Clamp01 restricts a value to the range [0, 1], as used for interpolation factors and colors.
+func Clamp01[T Float](value T) T {
+	return Clamp(value, 0, 1)
+}
+
+// Saturate is an alias of Clamp01, named after the equivalent HLSL shader intrinsic.
+func Saturate[T Float](value T) T {
+	return Clamp01(value)
+}
+
+// RoundToMultiple rounds a value to the nearest multiple of the given step.
+// Returns the value unchanged if multiple is zero.
+func RoundToMultiple(value, multiple float64) float64 {
+	if multiple == 0 {
+		return value
+	}
+	return math.Round(value/multiple) * multiple
+}
+
 // FastFloor performs a fast floor operation for floating-point numbers.
 func FastFloor[T Float, U Number](value T) U {
 	return U((value + 32768.0) - 32768)
@@ -140,6 +302,22 @@ func Min[T Number](v1, v2 T) T {
 	return v2
 }
 
+// Powers of Two
+// -------------
+// IsPowerOfTwo checks if n is a positive power of two.
+func IsPowerOfTwo(n int) bool {
+	return n > 0 && n&(n-1) == 0
+}
+
+// NextPowerOfTwo returns the smallest power of two greater than or equal to n.
+// Returns 1 if n is not positive.
+func NextPowerOfTwo(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	return 1 << bits.Len(uint(n-1))
+}
+
 // Vector and Angle
 // ----------------
 // AngleToVector converts an angle in radians to a vector with a given length.
@@ -169,3 +347,16 @@ func AdjustDestinationPixel(x float32) float32 {
 		return ix + 1
 	}
 }
+
+// Hashing
+// -------
+// mix64 scrambles the bits of h with the MurmurHash3 finalizer, so nearby inputs
+// produce unrelated outputs.
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xFF51AFD7ED558CCD
+	h ^= h >> 33
+	h *= 0xC4CEB9FE1A85EC53
+	h ^= h >> 33
+	return h
+}
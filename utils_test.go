@@ -0,0 +1,279 @@
+package ebimath
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInverseLerpRemap(t *testing.T) {
+	tests := []struct {
+		from, to, value, want float64
+	}{
+		{0, 10, 5, 0.5},
+		{10, 20, 25, 1.5},
+		{10, 0, 2, 0.8}, // reversed range
+		{3, 3, 7, 0},    // empty range
+	}
+	for _, tt := range tests {
+		if got := InverseLerp(tt.from, tt.to, tt.value); !approxEqual(got, tt.want) {
+			t.Errorf("InverseLerp(%v, %v, %v) = %v, want %v", tt.from, tt.to, tt.value, got, tt.want)
+		}
+	}
+
+	if got := Remap(75, 0, 100, 0, 200); !approxEqual(got, 150) {
+		t.Errorf("Remap to wider range = %v, want 150", got)
+	}
+	if got := Remap(75, 0, 100, 200, 0); !approxEqual(got, 50) {
+		t.Errorf("Remap to reversed output = %v, want 50", got)
+	}
+	if got := Remap(2, 10, 0, -1, 1); !approxEqual(got, 0.6) {
+		t.Errorf("Remap from reversed input = %v, want 0.6", got)
+	}
+}
+
+func TestPingPong(t *testing.T) {
+	tests := []struct {
+		t, want float64
+	}{
+		{0, 0},
+		{1, 1},
+		{2, 2},
+		{3, 1},
+		{4, 0},
+		{5.5, 1.5},
+		{10, 2},
+		{-1, 1},
+		{-3, 1},
+	}
+	for _, tt := range tests {
+		if got := PingPong(tt.t, 2); !approxEqual(got, tt.want) {
+			t.Errorf("PingPong(%v, 2) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+	// The wave is symmetric around each peak.
+	for _, d := range []float64{0.1, 0.7, 1.3} {
+		if a, b := PingPong(6-d, 2), PingPong(6+d, 2); !approxEqual(a, b) {
+			t.Errorf("PingPong not symmetric around 6: %v vs %v", a, b)
+		}
+	}
+	if got := PingPong(3, 0); got != 0 {
+		t.Errorf("PingPong(3, 0) = %v, want 0", got)
+	}
+}
+
+func TestWrapInt(t *testing.T) {
+	tests := []struct {
+		value, min, max, want int
+	}{
+		{3, 0, 5, 3},
+		{5, 0, 5, 0},
+		{12, 0, 5, 2},
+		{-1, 0, 5, 4},
+		{-11, 0, 5, 4},
+		{0, 2, 5, 3},
+		{7, 3, 3, 3}, // empty range
+	}
+	for _, tt := range tests {
+		if got := WrapInt(tt.value, tt.min, tt.max); got != tt.want {
+			t.Errorf("WrapInt(%d, %d, %d) = %d, want %d", tt.value, tt.min, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestDeltaAngle(t *testing.T) {
+	tests := []struct {
+		from, to, want float64
+	}{
+		{0, Pi / 2, Pi / 2},
+		{Pi / 2, 0, -Pi / 2},
+		{ToRadians(170), ToRadians(-170), ToRadians(20)},
+		{ToRadians(-170), ToRadians(170), ToRadians(-20)},
+		{0, 4 * Pi, 0},
+	}
+	for _, tt := range tests {
+		if got := DeltaAngle(tt.from, tt.to); !approxEqual(got, tt.want) {
+			t.Errorf("DeltaAngle(%v, %v) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestLerpAngleWrapsAround(t *testing.T) {
+	from, to := ToRadians(170), ToRadians(-170)
+	// A naive Lerp would pass through 0; the shortest path passes through 180.
+	got := LerpAngle(from, to, 0.5)
+	if !approxEqual(DeltaAngle(got, Pi), 0) {
+		t.Errorf("LerpAngle(170°, -170°, 0.5) = %v°, want 180°", ToDegrees(got))
+	}
+	if got := LerpAngle(from, to, 1); !approxEqual(DeltaAngle(got, to), 0) {
+		t.Errorf("LerpAngle(t=1) = %v°, want -170°", ToDegrees(got))
+	}
+}
+
+func TestMoveTowardsAngle(t *testing.T) {
+	step := ToRadians(5)
+	// Across the ±180° boundary, turning from 170° to -170° should increase the angle.
+	current, target := ToRadians(170), ToRadians(-170)
+	next := MoveTowardsAngle(current, target, step)
+	if !approxEqual(next, ToRadians(175)) {
+		t.Errorf("MoveTowardsAngle step = %v°, want 175°", ToDegrees(next))
+	}
+
+	// Repeated steps never overshoot and end exactly on the target.
+	for i := 0; i < 10; i++ {
+		before := math.Abs(DeltaAngle(current, target))
+		current = MoveTowardsAngle(current, target, step)
+		after := math.Abs(DeltaAngle(current, target))
+		if after > before {
+			t.Fatalf("step %d moved away from target: %v° -> %v°", i, ToDegrees(before), ToDegrees(after))
+		}
+	}
+	if !approxEqual(DeltaAngle(current, target), 0) {
+		t.Errorf("MoveTowardsAngle ended at %v°, want -170°", ToDegrees(current))
+	}
+	if got := MoveTowardsAngle(0, ToRadians(3), step); got != ToRadians(3) {
+		t.Errorf("MoveTowardsAngle within range = %v, want target", got)
+	}
+}
+
+func TestSmoothDampConverges(t *testing.T) {
+	current, velocity := 0.0, 0.0
+	for i := 0; i < 600; i++ {
+		next := SmoothDamp(current, 10, &velocity, 0.3, 1.0/60)
+		if next > 10 {
+			t.Fatalf("SmoothDamp overshot to %v at step %d", next, i)
+		}
+		current = next
+	}
+	if math.Abs(current-10) > 1e-3 {
+		t.Errorf("SmoothDamp ended at %v, want about 10", current)
+	}
+	if math.Abs(velocity) > 1e-2 {
+		t.Errorf("SmoothDamp velocity = %v, want about 0", velocity)
+	}
+}
+
+func TestSmoothDampVectorConverges(t *testing.T) {
+	current, target := V(0, 0), V(10, -5)
+	var velocity Vector
+	for i := 0; i < 600; i++ {
+		current = SmoothDampVector(current, target, &velocity, 0.3, 0, 1.0/60)
+	}
+	if current.DistanceTo(target) > 1e-3 {
+		t.Errorf("SmoothDampVector ended at %v, want about %v", current, target)
+	}
+	if velocity.Length() > 1e-2 {
+		t.Errorf("SmoothDampVector velocity = %v, want about zero", velocity)
+	}
+}
+
+func TestClamp01(t *testing.T) {
+	if got := Clamp01(-0.5); got != 0 {
+		t.Errorf("Clamp01(-0.5) = %v, want 0", got)
+	}
+	if got := Clamp01(float32(0.25)); got != 0.25 {
+		t.Errorf("Clamp01(0.25) = %v, want 0.25", got)
+	}
+	if got := Saturate(1.5); got != 1 {
+		t.Errorf("Saturate(1.5) = %v, want 1", got)
+	}
+}
+
+func TestPowerOfTwo(t *testing.T) {
+	tests := []struct {
+		n      int
+		isPow2 bool
+		next   int
+	}{
+		{-4, false, 1},
+		{0, false, 1},
+		{1, true, 1},
+		{2, true, 2},
+		{3, false, 4},
+		{63, false, 64},
+		{64, true, 64},
+		{65, false, 128},
+		{1 << 20, true, 1 << 20},
+		{1<<20 + 1, false, 1 << 21},
+	}
+	for _, tt := range tests {
+		if got := IsPowerOfTwo(tt.n); got != tt.isPow2 {
+			t.Errorf("IsPowerOfTwo(%d) = %v, want %v", tt.n, got, tt.isPow2)
+		}
+		if got := NextPowerOfTwo(tt.n); got != tt.next {
+			t.Errorf("NextPowerOfTwo(%d) = %d, want %d", tt.n, got, tt.next)
+		}
+	}
+}
+
+func TestRoundToMultiple(t *testing.T) {
+	tests := []struct {
+		value, multiple, want float64
+	}{
+		{1.1, 0.25, 1},
+		{1.13, 0.25, 1.25},
+		{-1.13, 0.25, -1.25},
+		{-7, 5, -5},
+		{17, 5, 15},
+		{3.7, 0, 3.7},
+	}
+	for _, tt := range tests {
+		if got := RoundToMultiple(tt.value, tt.multiple); !approxEqual(got, tt.want) {
+			t.Errorf("RoundToMultiple(%v, %v) = %v, want %v", tt.value, tt.multiple, got, tt.want)
+		}
+	}
+}
+
+func TestMapRange(t *testing.T) {
+	tests := []struct {
+		v, inMin, inMax, outMin, outMax, want float64
+	}{
+		{5, 0, 10, 0, 100, 50},
+		{0, 0, 10, -1, 1, -1},
+		{15, 0, 10, 0, 100, 150}, // not clamped
+		{2, 10, 0, 0, 100, 80},   // reversed input range
+		{2, 0, 10, 100, 0, 80},   // reversed output range
+		{3, 3, 3, 7, 9, 7},       // empty input range
+	}
+	for _, tt := range tests {
+		if got := MapRange(tt.v, tt.inMin, tt.inMax, tt.outMin, tt.outMax); !approxEqual(got, tt.want) {
+			t.Errorf("MapRange(%v, %v, %v, %v, %v) = %v, want %v", tt.v, tt.inMin, tt.inMax, tt.outMin, tt.outMax, got, tt.want)
+		}
+		got32 := MapRange(float32(tt.v), float32(tt.inMin), float32(tt.inMax), float32(tt.outMin), float32(tt.outMax))
+		if math.Abs(float64(got32)-tt.want) > 1e-4 {
+			t.Errorf("float32 MapRange(%v, %v, %v, %v, %v) = %v, want %v", tt.v, tt.inMin, tt.inMax, tt.outMin, tt.outMax, got32, tt.want)
+		}
+	}
+}
+
+func TestSmoothDampVectorMaxSpeed(t *testing.T) {
+	const maxSpeed, dt = 20.0, 1.0 / 60
+	current, target := V(0, 0), V(300, 400)
+	var velocity Vector
+	for i := 0; i < 3000; i++ {
+		next := SmoothDampVector(current, target, &velocity, 0.5, maxSpeed, dt)
+		if step := next.DistanceTo(current); step > maxSpeed*dt*1.01 {
+			t.Fatalf("step %d moved %v, want at most %v", i, step, maxSpeed*dt)
+		}
+		current = next
+	}
+	if current.DistanceTo(target) > 1e-3 {
+		t.Errorf("SmoothDampVector ended at %v, want about %v", current, target)
+	}
+}
+
+func TestApproach(t *testing.T) {
+	tests := []struct {
+		current, target, delta, want float64
+	}{
+		{0, 10, 3, 3},
+		{0, -10, 3, -3},
+		{9, 10, 3, 10}, // snaps within delta
+		{-9, -10, 3, -10},
+		{5, 5, 1, 5},
+	}
+	for _, tt := range tests {
+		if got := Approach(tt.current, tt.target, tt.delta); !approxEqual(got, tt.want) {
+			t.Errorf("Approach(%v, %v, %v) = %v, want %v", tt.current, tt.target, tt.delta, got, tt.want)
+		}
+	}
+}
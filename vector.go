@@ -1,6 +1,7 @@
 package ebimath
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math"
 )
@@ -48,6 +49,45 @@ func (self Vector) String() string {
 	return fmt.Sprintf("[%f, %f]", self.X, self.Y)
 }
 
+// MarshalBinary encodes the Vector as two little-endian float64 values (16 bytes).
+// It implements encoding.BinaryMarshaler.
+func (self Vector) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, 16)
+	binary.LittleEndian.PutUint64(buf[0:], math.Float64bits(self.X))
+	binary.LittleEndian.PutUint64(buf[8:], math.Float64bits(self.Y))
+	return buf, nil
+}
+
+// UnmarshalBinary decodes a Vector produced by MarshalBinary.
+// It implements encoding.BinaryUnmarshaler.
+func (self *Vector) UnmarshalBinary(data []byte) error {
+	if len(data) != 16 {
+		return fmt.Errorf("ebimath: invalid Vector binary length %d, expected 16", len(data))
+	}
+	self.X = math.Float64frombits(binary.LittleEndian.Uint64(data[0:]))
+	self.Y = math.Float64frombits(binary.LittleEndian.Uint64(data[8:]))
+	return nil
+}
+
+// MarshalBinary32 encodes the Vector as two little-endian float32 values (8 bytes).
+// This halves the size at the cost of precision, useful for network replication.
+func (self Vector) MarshalBinary32() []byte {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint32(buf[0:], math.Float32bits(float32(self.X)))
+	binary.LittleEndian.PutUint32(buf[4:], math.Float32bits(float32(self.Y)))
+	return buf
+}
+
+// UnmarshalBinary32 decodes a Vector produced by MarshalBinary32.
+func (self *Vector) UnmarshalBinary32(data []byte) error {
+	if len(data) != 8 {
+		return fmt.Errorf("ebimath: invalid Vector binary32 length %d, expected 8", len(data))
+	}
+	self.X = float64(math.Float32frombits(binary.LittleEndian.Uint32(data[0:])))
+	self.Y = float64(math.Float32frombits(binary.LittleEndian.Uint32(data[4:])))
+	return nil
+}
+
 // IsZero checks if the Vector is at the origin (0, 0).
 func (self Vector) IsZero() bool {
 	return self.X == 0 && self.Y == 0
@@ -58,11 +98,40 @@ func (self Vector) IsNormalized() bool {
 	return EqualsApproximately(self.LengthSquared(), 1)
 }
 
+// IsNaN checks if either component of the Vector is NaN.
+func (self Vector) IsNaN() bool {
+	return math.IsNaN(self.X) || math.IsNaN(self.Y)
+}
+
+// IsInf checks if either component of the Vector is positive or negative infinity.
+func (self Vector) IsInf() bool {
+	return math.IsInf(self.X, 0) || math.IsInf(self.Y, 0)
+}
+
+// Sanitized returns the fallback if either component is NaN or infinite,
+// otherwise it returns the Vector unchanged.
+func (self Vector) Sanitized(fallback Vector) Vector {
+	if self.IsNaN() || self.IsInf() {
+		return fallback
+	}
+	return self
+}
+
 // Abs returns a new Vector with the absolute values of X and Y.
 func (self Vector) Abs() Vector {
 	return V(math.Abs(self.X), math.Abs(self.Y))
 }
 
+// Min returns a new Vector with the component-wise minimum of this Vector and another.
+func (self Vector) Min(other Vector) Vector {
+	return V(math.Min(self.X, other.X), math.Min(self.Y, other.Y))
+}
+
+// Max returns a new Vector with the component-wise maximum of this Vector and another.
+func (self Vector) Max(other Vector) Vector {
+	return V(math.Max(self.X, other.X), math.Max(self.Y, other.Y))
+}
+
 // ToInt converts the Vector to integer coordinates.
 func (self Vector) ToInt() (int, int) {
 	return int(self.X), int(self.Y)
@@ -95,6 +164,21 @@ func (self Vector) RotateAround(around Vector, angle float64) Vector {
 	)
 }
 
+// Rotate90 rotates the Vector by 90 degrees without trigonometry, avoiding rounding residue.
+func (self Vector) Rotate90() Vector {
+	return V(-self.Y, self.X)
+}
+
+// RotateNeg90 rotates the Vector by -90 degrees without trigonometry.
+func (self Vector) RotateNeg90() Vector {
+	return V(self.Y, -self.X)
+}
+
+// Rotate180 rotates the Vector by 180 degrees without trigonometry.
+func (self Vector) Rotate180() Vector {
+	return V(-self.X, -self.Y)
+}
+
 // DistanceTo calculates the Euclidean distance to another Vector.
 func (self Vector) DistanceTo(v2 Vector) float64 {
 	return math.Sqrt(self.DistanceSquaredTo(v2))
@@ -106,6 +190,35 @@ func (self Vector) DistanceSquaredTo(v2 Vector) float64 {
 	return dx*dx + dy*dy
 }
 
+// ManhattanDistanceTo computes the taxicab distance (|dx| + |dy|) to another Vector.
+// This is the admissible heuristic for 4-connected grids.
+func (self Vector) ManhattanDistanceTo(v2 Vector) float64 {
+	return math.Abs(self.X-v2.X) + math.Abs(self.Y-v2.Y)
+}
+
+// ChebyshevDistanceTo computes the chessboard distance (max(|dx|, |dy|)) to another Vector.
+// This is the admissible heuristic for 8-connected grids.
+func (self Vector) ChebyshevDistanceTo(v2 Vector) float64 {
+	return math.Max(math.Abs(self.X-v2.X), math.Abs(self.Y-v2.Y))
+}
+
+// ClosestPointOnSegment returns the point on segment ab closest to this Vector.
+// If a and b are the same point, a is returned.
+func (self Vector) ClosestPointOnSegment(a, b Vector) Vector {
+	ab := b.Sub(a)
+	l := ab.LengthSquared()
+	if l == 0 {
+		return a
+	}
+	t := Clamp(self.Sub(a).Dot(ab)/l, 0, 1)
+	return a.Add(ab.ScaleF(t))
+}
+
+// DistanceToSegment computes the shortest distance from this Vector to segment ab.
+func (self Vector) DistanceToSegment(a, b Vector) float64 {
+	return self.DistanceTo(self.ClosestPointOnSegment(a, b))
+}
+
 // Dot computes the dot product between this Vector and another.
 func (self Vector) Dot(v2 Vector) float64 {
 	return self.X*v2.X + self.Y*v2.Y
@@ -126,11 +239,34 @@ func (self Vector) Angle() float64 {
 	return math.Atan2(self.Y, self.X)
 }
 
+// AngleFrom returns the signed angle in radians of this Vector measured from a reference
+// axis, in the range [-Pi, Pi]. Angle is the special case of an axis along +X.
+// Returns 0 if either vector is zero.
+func (self Vector) AngleFrom(axis Vector) float64 {
+	return axis.SignedAngleBetween(self)
+}
+
 // AngleToPoint returns the angle from this Vector towards another point.
 func (self Vector) AngleToPoint(other Vector) float64 {
 	return other.Sub(self).Angle()
 }
 
+// AngleBetween returns the unsigned angle in radians between this Vector and another.
+// Returns 0 if either vector is zero.
+func (self Vector) AngleBetween(other Vector) float64 {
+	return math.Abs(self.SignedAngleBetween(other))
+}
+
+// SignedAngleBetween returns the signed angle in radians from this Vector to another,
+// in the range [-Pi, Pi]. Returns 0 if either vector is zero.
+func (self Vector) SignedAngleBetween(other Vector) float64 {
+	if self.IsZero() || other.IsZero() {
+		return 0
+	}
+	// Atan2 of cross and dot stays accurate near 0 and Pi, unlike Acos.
+	return math.Atan2(self.Cross(other), self.Dot(other))
+}
+
 // DirectionTo returns a normalized vector pointing from this Vector to another.
 func (self Vector) DirectionTo(other Vector) Vector {
 	return other.Sub(self).Normalize()
@@ -152,6 +288,17 @@ func (self Vector) MoveTowards(other Vector, length float64) Vector {
 	return self.Add(direction.DivF(dist).ScaleF(length))
 }
 
+// MoveTowardsEx works like MoveTowards but also returns the distance left unused
+// after reaching other, which is 0 if other was not reached. This allows chaining
+// movement across several waypoints within a single step.
+func (self Vector) MoveTowardsEx(other Vector, length float64) (Vector, float64) {
+	dist := self.DistanceTo(other)
+	if dist <= length || dist < Epsilon {
+		return other, math.Max(0, length-dist)
+	}
+	return self.MoveTowards(other, length), 0
+}
+
 // Negate returns a new Vector with both components negated.
 func (self Vector) Negate() Vector {
 	return V(-self.X, -self.Y)
@@ -216,11 +363,28 @@ func (self Vector) Normalize() Vector {
 	return self
 }
 
-// Lerp performs linear interpolation between two vectors.
-// t is the interpolation factor, typically between 0 and 1.
-func (v Vector) Lerp(other Vector, t float64) Vector {
-	// The formula is: v + (other - v) * t
-	return v.Add(other.Sub(v).ScaleF(t))
+// Lerp performs component-wise linear interpolation between two vectors.
+// t is not clamped, so values outside [0, 1] extrapolate beyond the endpoints.
+func (self Vector) Lerp(to Vector, t float64) Vector {
+	// The formula is: self + (to - self) * t
+	return self.Add(to.Sub(self).ScaleF(t))
+}
+
+// LerpClamped performs linear interpolation with t clamped to [0, 1].
+func (self Vector) LerpClamped(to Vector, t float64) Vector {
+	return self.Lerp(to, Clamp(t, 0, 1))
+}
+
+// Slerp interpolates the direction and length of this Vector towards another separately,
+// rotating at a constant angular rate along the shortest arc. Opposite vectors swing
+// through a half turn without degenerating. If either vector is zero it falls back to Lerp.
+func (self Vector) Slerp(to Vector, t float64) Vector {
+	if self.IsZero() || to.IsZero() {
+		return self.Lerp(to, t)
+	}
+	fromLength, toLength := self.Length(), to.Length()
+	angle := self.SignedAngleBetween(to)
+	return self.Rotate(angle * t).ScaleF(Lerp(fromLength, toLength, t) / fromLength)
 }
 
 // ClampLength ensures the Vector's length does not exceed a given limit.
@@ -232,6 +396,64 @@ func (self Vector) ClampLength(limit float64) Vector {
 	return self
 }
 
+// ClampLengthRange ensures the Vector's length stays within [min, max].
+// If min is greater than max the bounds are swapped. A zero vector stays zero.
+func (self Vector) ClampLengthRange(min, max float64) Vector {
+	if min > max {
+		min, max = max, min
+	}
+	l := self.Length()
+	if l == 0 {
+		return self
+	}
+	if l < min {
+		return self.ScaleF(min / l)
+	}
+	if l > max {
+		return self.ScaleF(max / l)
+	}
+	return self
+}
+
+// SetLength returns a Vector in the same direction with the given length.
+// A zero vector returns zero rather than NaN.
+func (self Vector) SetLength(length float64) Vector {
+	l := self.Length()
+	if l == 0 {
+		return self
+	}
+	return self.ScaleF(length / l)
+}
+
+// Clamp returns the Vector with X clamped into [min.X, max.X] and Y into [min.Y, max.Y]
+// independently. If min is greater than max on an axis, that axis is set to min.
+func (self Vector) Clamp(min, max Vector) Vector {
+	return V(
+		math.Max(min.X, math.Min(self.X, max.X)),
+		math.Max(min.Y, math.Min(self.Y, max.Y)),
+	)
+}
+
+// ClampRect returns the Vector with each component clamped into the rectangle's bounds.
+// Inverted rectangles are normalized first. The rectangle's Angle is ignored.
+func (self Vector) ClampRect(r Rectangle) Vector {
+	return r.Normalized().ClosestPoint(self)
+}
+
+// WrapRect wraps each component around the rectangle's bounds, so a Vector leaving
+// one edge reappears on the opposite edge. An axis with no size collapses to Min.
+func (self Vector) WrapRect(r Rectangle) Vector {
+	r = r.Normalized()
+	result := r.Min
+	if w := r.Width(); w > 0 {
+		result.X += Repeat(self.X-r.Min.X, w)
+	}
+	if h := r.Height(); h > 0 {
+		result.Y += Repeat(self.Y-r.Min.Y, h)
+	}
+	return result
+}
+
 // Extend adds magnitude to the Vector in the direction it's already pointing.
 func (self Vector) Extend(length float64) Vector {
 	return self.Add(self.Normalize().ScaleF(length))
@@ -251,11 +473,13 @@ func (self Vector) Round() Vector {
 }
 
 // Floor returns a new Vector with each component rounded down to the nearest integer.
+// Rounding is towards negative infinity, so -0.5 floors to -1.
 func (self Vector) Floor() Vector {
 	return V(math.Floor(self.X), math.Floor(self.Y))
 }
 
 // Ceil returns a new Vector with each component rounded up to the nearest integer.
+// Rounding is towards positive infinity, so -0.5 ceils to -0.
 func (self Vector) Ceil() Vector {
 	return V(math.Ceil(self.X), math.Ceil(self.Y))
 }
@@ -265,9 +489,41 @@ func (self Vector) MoveInDirection(angle, distance float64) Vector {
 	return self.Add(V(math.Cos(angle), math.Sin(angle)).ScaleF(distance))
 }
 
+// Hash quantizes the Vector to a fine grid (cells of 1e-6) and returns a hash of that cell,
+// suitable for deduplicating positions in a map. See HashGrid for details.
+func (self Vector) Hash() uint64 {
+	return self.HashGrid(1e-6)
+}
+
+// HashGrid returns a hash of the grid cell of the given size containing this Vector.
+// Vectors share a hash only when they fall in the same cell, so two nearby Vectors on
+// opposite sides of a cell boundary hash differently. A non-positive cellSize hashes
+// the exact component bits.
+func (self Vector) HashGrid(cellSize float64) uint64 {
+	var x, y uint64
+	if cellSize > 0 {
+		x = uint64(int64(math.Floor(self.X / cellSize)))
+		y = uint64(int64(math.Floor(self.Y / cellSize)))
+	} else {
+		x, y = math.Float64bits(self.X), math.Float64bits(self.Y)
+	}
+	// Combine the cell coordinates and scramble them.
+	return mix64(x*0x9E3779B97F4A7C15 ^ y)
+}
+
 // Equals checks if two Vectors are equal within a small tolerance.
 func (self Vector) Equals(other Vector) bool {
-	return self.Sub(other).LengthSquared() < 0.00000000009
+	return self.withinSquared(other, 0.00000000009)
+}
+
+// EqualsApprox checks if two Vectors are within epsilon distance of each other.
+func (self Vector) EqualsApprox(other Vector, epsilon float64) bool {
+	return self.withinSquared(other, epsilon*epsilon)
+}
+
+// withinSquared checks if the squared distance to other is below limitSquared.
+func (self Vector) withinSquared(other Vector, limitSquared float64) bool {
+	return self.Sub(other).LengthSquared() < limitSquared
 }
 
 // Reflect reflects the vector against the given surface normal.
@@ -276,10 +532,62 @@ func (self Vector) Reflect(normal Vector) Vector {
 	return self.Sub(n.ScaleF(2 * n.Dot(self)))
 }
 
+// Orthogonal returns a perpendicular Vector. It is equivalent to Rotate90.
 func (self Vector) Orthogonal() Vector {
-	return V(-self.Y, self.X)
+	return self.Rotate90()
 }
 
+// Cross returns the 2D scalar cross product (the Z component of the 3D cross product).
+// It is positive when other is counter-clockwise from this Vector, negative when
+// clockwise, and zero when the vectors are collinear.
 func (self Vector) Cross(other Vector) float64 {
 	return self.X*other.Y - self.Y*other.X
 }
+
+// MinVector returns the component-wise minimum of all given vectors.
+// With no arguments it returns the zero vector.
+func MinVector(vs ...Vector) Vector {
+	if len(vs) == 0 {
+		return ZeroVector
+	}
+	result := vs[0]
+	for _, v := range vs[1:] {
+		result = result.Min(v)
+	}
+	return result
+}
+
+// MaxVector returns the component-wise maximum of all given vectors.
+// With no arguments it returns the zero vector.
+func MaxVector(vs ...Vector) Vector {
+	if len(vs) == 0 {
+		return ZeroVector
+	}
+	result := vs[0]
+	for _, v := range vs[1:] {
+		result = result.Max(v)
+	}
+	return result
+}
+
+// AddAll stores the element-wise sum of a and b in dst. dst may alias a or b.
+// Only the first min(len(dst), len(a), len(b)) elements are processed.
+func AddAll(dst, a, b []Vector) {
+	n := min(len(dst), len(a), len(b))
+	for i := 0; i < n; i++ {
+		dst[i] = Vector{a[i].X + b[i].X, a[i].Y + b[i].Y}
+	}
+}
+
+// ApplyAll stores each Vector of src transformed by m in dst. dst may alias src.
+// Only the first min(len(dst), len(src)) elements are processed.
+func ApplyAll(dst, src []Vector, m Matrix) {
+	// Read the coefficients once instead of calling Matrix.Apply per element.
+	a, b, tx := m.Element(0, 0), m.Element(0, 1), m.Element(0, 2)
+	c, d, ty := m.Element(1, 0), m.Element(1, 1), m.Element(1, 2)
+	n := min(len(dst), len(src))
+	for i := 0; i < n; i++ {
+		x, y := src[i].X, src[i].Y
+		dst[i] = Vector{a*x + b*y + tx, c*x + d*y + ty}
+	}
+}
@@ -0,0 +1,578 @@
+package ebimath
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// approxEqual reports whether two floats are within a tight tolerance.
+func approxEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+// vectorApproxEqual reports whether two Vectors are within a tight tolerance.
+func vectorApproxEqual(a, b Vector) bool {
+	return approxEqual(a.X, b.X) && approxEqual(a.Y, b.Y)
+}
+
+func TestVectorLerp(t *testing.T) {
+	from, to := V(0, 10), V(10, -10)
+	tests := []struct {
+		t    float64
+		want Vector
+	}{
+		{0, V(0, 10)},
+		{1, V(10, -10)},
+		{0.5, V(5, 0)},
+		{1.5, V(15, -20)},
+	}
+	for _, tt := range tests {
+		if got := from.Lerp(to, tt.t); !vectorApproxEqual(got, tt.want) {
+			t.Errorf("Lerp(%v, %v) = %v, want %v", to, tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestVectorLerpClamped(t *testing.T) {
+	from, to := V(0, 10), V(10, -10)
+	if got := from.LerpClamped(to, 1.5); !vectorApproxEqual(got, to) {
+		t.Errorf("LerpClamped(t=1.5) = %v, want %v", got, to)
+	}
+	if got := from.LerpClamped(to, -0.5); !vectorApproxEqual(got, from) {
+		t.Errorf("LerpClamped(t=-0.5) = %v, want %v", got, from)
+	}
+	if got := from.LerpClamped(to, 0.5); !vectorApproxEqual(got, V(5, 0)) {
+		t.Errorf("LerpClamped(t=0.5) = %v, want %v", got, V(5, 0))
+	}
+}
+
+func TestVectorCross(t *testing.T) {
+	a, b := V(1, 0), V(0, 1)
+	if got := a.Cross(b); got != 1 {
+		t.Errorf("Cross = %v, want 1", got)
+	}
+	if got := b.Cross(a); got != -1 {
+		t.Errorf("swapped Cross = %v, want -1", got)
+	}
+	if got := V(2, 4).Cross(V(-1, -2)); got != 0 {
+		t.Errorf("collinear Cross = %v, want 0", got)
+	}
+}
+
+func TestVectorAngleBetween(t *testing.T) {
+	tests := []struct {
+		a, b           Vector
+		signed, absVal float64
+	}{
+		{V(1, 0), V(0, 1), Pi / 2, Pi / 2},
+		{V(0, 1), V(1, 0), -Pi / 2, Pi / 2},
+		{V(1, 0), V(1, 0), 0, 0},
+		{V(1, 0), V(-1, 0), Pi, Pi},
+		{V(1, 0), V(1, 1e-12), 1e-12, 1e-12},
+		{V(1, 0), ZeroVector, 0, 0},
+		{ZeroVector, V(1, 0), 0, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.a.SignedAngleBetween(tt.b); !approxEqual(got, tt.signed) {
+			t.Errorf("%v.SignedAngleBetween(%v) = %v, want %v", tt.a, tt.b, got, tt.signed)
+		}
+		if got := tt.a.AngleBetween(tt.b); !approxEqual(got, tt.absVal) {
+			t.Errorf("%v.AngleBetween(%v) = %v, want %v", tt.a, tt.b, got, tt.absVal)
+		}
+	}
+}
+
+func TestVectorMinMax(t *testing.T) {
+	a, b := V(-3, 5), V(2, -7)
+	if got, want := a.Min(b), V(-3, -7); got != want {
+		t.Errorf("Min = %v, want %v", got, want)
+	}
+	if got, want := a.Max(b), V(2, 5); got != want {
+		t.Errorf("Max = %v, want %v", got, want)
+	}
+}
+
+func TestVectorFloorCeil(t *testing.T) {
+	tests := []struct {
+		v, floor, ceil Vector
+	}{
+		{V(-0.5, 0.5), V(-1, 0), V(0, 1)},
+		{V(-1, 1), V(-1, 1), V(-1, 1)},
+		{V(-1.5, 2.25), V(-2, 2), V(-1, 3)},
+	}
+	for _, tt := range tests {
+		if got := tt.v.Floor(); got != tt.floor {
+			t.Errorf("%v.Floor() = %v, want %v", tt.v, got, tt.floor)
+		}
+		if got := tt.v.Ceil(); got != tt.ceil {
+			t.Errorf("%v.Ceil() = %v, want %v", tt.v, got, tt.ceil)
+		}
+	}
+}
+
+func TestVectorGridDistances(t *testing.T) {
+	a, b := V(1, 1), V(4, 5)
+	if got := a.ManhattanDistanceTo(b); got != 7 {
+		t.Errorf("ManhattanDistanceTo = %v, want 7", got)
+	}
+	if got := a.ChebyshevDistanceTo(b); got != 4 {
+		t.Errorf("ChebyshevDistanceTo = %v, want 4", got)
+	}
+	if got := b.ManhattanDistanceTo(a); got != 7 {
+		t.Errorf("reversed ManhattanDistanceTo = %v, want 7", got)
+	}
+}
+
+func TestVectorNegate(t *testing.T) {
+	v := V(3, -4)
+	if got := v.Negate(); got != V(-3, 4) {
+		t.Errorf("Negate = %v, want %v", got, V(-3, 4))
+	}
+	if got := v.Negate().Negate(); got != v {
+		t.Errorf("double Negate = %v, want %v", got, v)
+	}
+}
+
+func TestVectorMarshalBinary(t *testing.T) {
+	v := V(1.5, -2.25)
+	data, err := v.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary error: %v", err)
+	}
+	want := make([]byte, 16)
+	binary.LittleEndian.PutUint64(want[0:], math.Float64bits(1.5))
+	binary.LittleEndian.PutUint64(want[8:], math.Float64bits(-2.25))
+	if !bytes.Equal(data, want) {
+		t.Errorf("MarshalBinary = %x, want %x", data, want)
+	}
+
+	var got Vector
+	if err := got.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary error: %v", err)
+	}
+	if got != v {
+		t.Errorf("round trip = %v, want %v", got, v)
+	}
+	if err := got.UnmarshalBinary(data[:15]); err == nil {
+		t.Error("UnmarshalBinary accepted a 15-byte buffer")
+	}
+}
+
+func TestVectorMarshalBinary32(t *testing.T) {
+	v := V(1.5, -2.25)
+	data := v.MarshalBinary32()
+	want := make([]byte, 8)
+	binary.LittleEndian.PutUint32(want[0:], math.Float32bits(1.5))
+	binary.LittleEndian.PutUint32(want[4:], math.Float32bits(-2.25))
+	if !bytes.Equal(data, want) {
+		t.Errorf("MarshalBinary32 = %x, want %x", data, want)
+	}
+
+	var got Vector
+	if err := got.UnmarshalBinary32(data); err != nil {
+		t.Fatalf("UnmarshalBinary32 error: %v", err)
+	}
+	if got != v {
+		t.Errorf("round trip = %v, want %v", got, v)
+	}
+	if err := got.UnmarshalBinary32(append(data, 0)); err == nil {
+		t.Error("UnmarshalBinary32 accepted a 9-byte buffer")
+	}
+}
+
+func TestVectorSetLength(t *testing.T) {
+	if got := V(3, 4).SetLength(10); !vectorApproxEqual(got, V(6, 8)) {
+		t.Errorf("SetLength(10) = %v, want %v", got, V(6, 8))
+	}
+	if got := ZeroVector.SetLength(10); got != ZeroVector {
+		t.Errorf("zero SetLength = %v, want zero", got)
+	}
+}
+
+func TestVectorClampLengthRange(t *testing.T) {
+	tests := []struct {
+		v        Vector
+		min, max float64
+		want     float64
+	}{
+		{V(3, 4), 1, 10, 5},
+		{V(3, 4), 6, 10, 6},
+		{V(3, 4), 1, 2, 2},
+		{V(3, 4), 10, 6, 6}, // min > max is swapped
+		{V(3, 4), 2, 1, 2},
+	}
+	for _, tt := range tests {
+		if got := tt.v.ClampLengthRange(tt.min, tt.max).Length(); !approxEqual(got, tt.want) {
+			t.Errorf("ClampLengthRange(%v, %v) length = %v, want %v", tt.min, tt.max, got, tt.want)
+		}
+	}
+	if got := ZeroVector.ClampLengthRange(1, 2); got != ZeroVector {
+		t.Errorf("zero ClampLengthRange = %v, want zero", got)
+	}
+}
+
+func TestVectorClosestPointOnSegment(t *testing.T) {
+	a, b := V(0, 0), V(10, 0)
+	tests := []struct {
+		name string
+		p    Vector
+		want Vector
+		dist float64
+	}{
+		{"before a", V(-5, 3), a, math.Hypot(5, 3)},
+		{"after b", V(15, -4), b, math.Hypot(5, 4)},
+		{"middle", V(4, 3), V(4, 0), 3},
+	}
+	for _, tt := range tests {
+		if got := tt.p.ClosestPointOnSegment(a, b); !vectorApproxEqual(got, tt.want) {
+			t.Errorf("%s: ClosestPointOnSegment = %v, want %v", tt.name, got, tt.want)
+		}
+		if got := tt.p.DistanceToSegment(a, b); !approxEqual(got, tt.dist) {
+			t.Errorf("%s: DistanceToSegment = %v, want %v", tt.name, got, tt.dist)
+		}
+	}
+	if got := V(3, 3).ClosestPointOnSegment(a, a); got != a {
+		t.Errorf("degenerate segment = %v, want %v", got, a)
+	}
+}
+
+func TestVectorRotate90(t *testing.T) {
+	v := V(0.1, -7.3)
+	if got := v.Rotate90().Rotate90().Rotate90().Rotate90(); got != v {
+		t.Errorf("four Rotate90 = %v, want exactly %v", got, v)
+	}
+	if got := V(1, 0).Rotate90(); got != V(0, 1) {
+		t.Errorf("Rotate90 = %v, want %v", got, V(0, 1))
+	}
+	if got := V(1, 0).RotateNeg90(); got != V(0, -1) {
+		t.Errorf("RotateNeg90 = %v, want %v", got, V(0, -1))
+	}
+	if got := v.Rotate180(); got != V(-0.1, 7.3) {
+		t.Errorf("Rotate180 = %v, want %v", got, V(-0.1, 7.3))
+	}
+	if got := v.Rotate90().RotateNeg90(); got != v {
+		t.Errorf("Rotate90 then RotateNeg90 = %v, want %v", got, v)
+	}
+}
+
+func TestVectorEqualsApprox(t *testing.T) {
+	a, b := V(1, 1), V(1.001, 1)
+	if !a.EqualsApprox(b, 0.01) {
+		t.Error("EqualsApprox(0.01) = false, want true")
+	}
+	if a.EqualsApprox(b, 0.0001) {
+		t.Error("EqualsApprox(0.0001) = true, want false")
+	}
+}
+
+func TestVectorEqualsDefaultThreshold(t *testing.T) {
+	// Equals compares the squared distance against the original fixed threshold.
+	if !V(0, 0).Equals(V(9e-6, 0)) {
+		t.Error("Equals within threshold = false, want true")
+	}
+	if V(0, 0).Equals(V(1e-5, 0)) {
+		t.Error("Equals past threshold = true, want false")
+	}
+}
+
+func TestVectorSanitized(t *testing.T) {
+	fallback := V(1, 2)
+	nanX := V(math.NaN(), 0)
+	infY := V(0, math.Inf(-1))
+	clean := V(3, 4)
+
+	if !nanX.IsNaN() || nanX.IsInf() {
+		t.Errorf("%v: IsNaN = %v, IsInf = %v", nanX, nanX.IsNaN(), nanX.IsInf())
+	}
+	if infY.IsNaN() || !infY.IsInf() {
+		t.Errorf("%v: IsNaN = %v, IsInf = %v", infY, infY.IsNaN(), infY.IsInf())
+	}
+	if clean.IsNaN() || clean.IsInf() {
+		t.Errorf("%v: IsNaN = %v, IsInf = %v", clean, clean.IsNaN(), clean.IsInf())
+	}
+
+	if got := nanX.Sanitized(fallback); got != fallback {
+		t.Errorf("NaN Sanitized = %v, want %v", got, fallback)
+	}
+	if got := infY.Sanitized(fallback); got != fallback {
+		t.Errorf("Inf Sanitized = %v, want %v", got, fallback)
+	}
+	if got := clean.Sanitized(fallback); got != clean {
+		t.Errorf("clean Sanitized = %v, want %v", got, clean)
+	}
+}
+
+func TestVectorClampRect(t *testing.T) {
+	r := NewRectangle(0, 0, 10, 5)
+	tests := []struct {
+		name    string
+		v, want Vector
+	}{
+		{"inside", V(3, 2), V(3, 2)},
+		{"left", V(-4, 2), V(0, 2)},
+		{"right", V(14, 2), V(10, 2)},
+		{"top", V(3, -1), V(3, 0)},
+		{"bottom", V(3, 9), V(3, 5)},
+		{"corner", V(-4, 9), V(0, 5)},
+	}
+	for _, tt := range tests {
+		if got := tt.v.ClampRect(r); !vectorApproxEqual(got, tt.want) {
+			t.Errorf("%s: ClampRect(%v) = %v, want %v", tt.name, tt.v, got, tt.want)
+		}
+	}
+	if got := V(14, 9).ClampRect(NewRectangle(10, 5, 0, 0)); !vectorApproxEqual(got, V(10, 5)) {
+		t.Errorf("inverted rectangle ClampRect = %v, want %v", got, V(10, 5))
+	}
+}
+
+func TestVectorWrapRect(t *testing.T) {
+	r := NewRectangle(0, 0, 10, 5)
+	tests := []struct {
+		v, want Vector
+	}{
+		{V(3, 2), V(3, 2)},
+		{V(12, 2), V(2, 2)},
+		{V(-1, 2), V(9, 2)},
+		{V(3, -1), V(3, 4)},
+		{V(45, 17), V(5, 2)},
+		{V(-37, -13), V(3, 2)}, // several widths out on the negative side
+		{V(10, 5), V(0, 0)},
+	}
+	for _, tt := range tests {
+		if got := tt.v.WrapRect(r); !vectorApproxEqual(got, tt.want) {
+			t.Errorf("WrapRect(%v) = %v, want %v", tt.v, got, tt.want)
+		}
+	}
+	if got := V(7, 7).WrapRect(NewRectangle(2, 0, 2, 5)); !vectorApproxEqual(got, V(2, 2)) {
+		t.Errorf("zero-width WrapRect = %v, want %v", got, V(2, 2))
+	}
+}
+
+func TestVectorHashGrid(t *testing.T) {
+	const cell = 10
+	if V(1, 2).HashGrid(cell) != V(9.5, 0.1).HashGrid(cell) {
+		t.Error("points in the same cell hash differently")
+	}
+	if V(1, 2).HashGrid(cell) == V(11, 2).HashGrid(cell) {
+		t.Error("points in neighboring cells share a hash")
+	}
+	if V(-1, 2).HashGrid(cell) == V(1, 2).HashGrid(cell) {
+		t.Error("points either side of zero share a hash")
+	}
+	if V(15, 25).HashGrid(cell) == V(25, 15).HashGrid(cell) {
+		t.Error("swapped cell coordinates share a hash")
+	}
+	if V(0.1, 0.2).Hash() != V(0.1, 0.2).Hash() {
+		t.Error("Hash is not deterministic")
+	}
+	if V(0.1, 0.2).Hash() == V(0.1, 0.3).Hash() {
+		t.Error("distant points share a Hash")
+	}
+}
+
+func TestAddAllApplyAll(t *testing.T) {
+	a := []Vector{V(1, 2), V(-3, 4), V(0.5, -0.25)}
+	b := []Vector{V(10, 20), V(1, 1), V(-2, 8)}
+	m := NewMatrix(V(5, -7), 0.6, V(2, 0.5))
+
+	dst := make([]Vector, len(a))
+	AddAll(dst, a, b)
+	for i := range a {
+		if want := a[i].Add(b[i]); dst[i] != want {
+			t.Errorf("AddAll[%d] = %v, want %v", i, dst[i], want)
+		}
+	}
+	ApplyAll(dst, a, m)
+	for i := range a {
+		if want := a[i].Apply(m); !vectorApproxEqual(dst[i], want) {
+			t.Errorf("ApplyAll[%d] = %v, want %v", i, dst[i], want)
+		}
+	}
+
+	// Aliased destinations must give the same result.
+	aliased := append([]Vector(nil), a...)
+	AddAll(aliased, aliased, b)
+	for i := range a {
+		if want := a[i].Add(b[i]); aliased[i] != want {
+			t.Errorf("aliased AddAll[%d] = %v, want %v", i, aliased[i], want)
+		}
+	}
+	aliased = append(aliased[:0], a...)
+	ApplyAll(aliased, aliased, m)
+	for i := range a {
+		if want := a[i].Apply(m); !vectorApproxEqual(aliased[i], want) {
+			t.Errorf("aliased ApplyAll[%d] = %v, want %v", i, aliased[i], want)
+		}
+	}
+
+	// Only the shortest length is processed.
+	short := []Vector{V(9, 9), V(9, 9), V(9, 9)}
+	AddAll(short, a[:2], b)
+	if short[2] != V(9, 9) {
+		t.Errorf("AddAll wrote past the shortest slice: %v", short)
+	}
+}
+
+func benchmarkVectors(n int) []Vector {
+	vs := make([]Vector, n)
+	for i := range vs {
+		vs[i] = V(float64(i), float64(-i)*0.5)
+	}
+	return vs
+}
+
+func BenchmarkAddAll(b *testing.B) {
+	x, y := benchmarkVectors(1024), benchmarkVectors(1024)
+	dst := make([]Vector, len(x))
+	for i := 0; i < b.N; i++ {
+		AddAll(dst, x, y)
+	}
+}
+
+func BenchmarkAddLoop(b *testing.B) {
+	x, y := benchmarkVectors(1024), benchmarkVectors(1024)
+	dst := make([]Vector, len(x))
+	for i := 0; i < b.N; i++ {
+		for j := range dst {
+			dst[j] = x[j].Add(y[j])
+		}
+	}
+}
+
+func BenchmarkApplyAll(b *testing.B) {
+	src := benchmarkVectors(1024)
+	dst := make([]Vector, len(src))
+	m := NewMatrix(V(5, -7), 0.6, V(2, 0.5))
+	for i := 0; i < b.N; i++ {
+		ApplyAll(dst, src, m)
+	}
+}
+
+func BenchmarkApplyLoop(b *testing.B) {
+	src := benchmarkVectors(1024)
+	dst := make([]Vector, len(src))
+	m := NewMatrix(V(5, -7), 0.6, V(2, 0.5))
+	for i := 0; i < b.N; i++ {
+		for j := range dst {
+			dst[j] = src[j].Apply(m)
+		}
+	}
+}
+
+func TestVectorAngleFrom(t *testing.T) {
+	axis := V(1, 1) // 45° reference
+	tests := []struct {
+		v    Vector
+		want float64
+	}{
+		{V(2, 2), 0},
+		{V(-1, 1), Pi / 2},
+		{V(1, -1), -Pi / 2},
+		{V(0, 1), Pi / 4},
+		{V(1, 0), -Pi / 4},
+		{V(-1, -1), Pi},
+	}
+	for _, tt := range tests {
+		if got := tt.v.AngleFrom(axis); !approxEqual(math.Abs(got), math.Abs(tt.want)) || (tt.want != Pi && !approxEqual(got, tt.want)) {
+			t.Errorf("%v.AngleFrom(%v) = %v, want %v", tt.v, axis, got, tt.want)
+		}
+	}
+	if got, want := V(3, 4).AngleFrom(V(1, 0)), V(3, 4).Angle(); !approxEqual(got, want) {
+		t.Errorf("AngleFrom(+X) = %v, want Angle() = %v", got, want)
+	}
+	if got := V(3, 4).AngleFrom(ZeroVector); got != 0 {
+		t.Errorf("AngleFrom(zero) = %v, want 0", got)
+	}
+}
+
+func TestVectorClamp(t *testing.T) {
+	min, max := V(0, -5), V(10, 5)
+	tests := []struct {
+		v, want Vector
+	}{
+		{V(3, 2), V(3, 2)},
+		{V(-4, 2), V(0, 2)},
+		{V(3, 9), V(3, 5)},
+		{V(20, -9), V(10, -5)}, // each axis clamps independently
+	}
+	for _, tt := range tests {
+		if got := tt.v.Clamp(min, max); got != tt.want {
+			t.Errorf("%v.Clamp(%v, %v) = %v, want %v", tt.v, min, max, got, tt.want)
+		}
+	}
+	if got := V(3, 3).Clamp(V(5, 0), V(1, 10)); got != V(5, 3) {
+		t.Errorf("Clamp with min.X > max.X = %v, want %v", got, V(5, 3))
+	}
+}
+
+func TestVectorSlerp(t *testing.T) {
+	from, to := V(1, 0), V(0, 1)
+	for i := 0; i <= 10; i++ {
+		if got := from.Slerp(to, float64(i)/10).Length(); !approxEqual(got, 1) {
+			t.Errorf("Slerp(t=%v) length = %v, want 1", float64(i)/10, got)
+		}
+	}
+	if got, want := from.Slerp(to, 0.5), V(math.Sqrt2/2, math.Sqrt2/2); !vectorApproxEqual(got, want) {
+		t.Errorf("Slerp(t=0.5) = %v, want %v", got, want)
+	}
+	if got := from.Slerp(to, 1); !vectorApproxEqual(got, to) {
+		t.Errorf("Slerp(t=1) = %v, want %v", got, to)
+	}
+
+	// Opposite vectors swing through a half turn instead of passing through zero.
+	mid := from.Slerp(V(-1, 0), 0.5)
+	if !approxEqual(mid.Length(), 1) || !approxEqual(math.Abs(mid.Y), 1) {
+		t.Errorf("opposite Slerp(t=0.5) = %v, want a unit vector along Y", mid)
+	}
+
+	if got := V(2, 0).Slerp(V(0, 4), 0.5).Length(); !approxEqual(got, 3) {
+		t.Errorf("Slerp length = %v, want the interpolated length 3", got)
+	}
+	if got := ZeroVector.Slerp(V(4, 0), 0.5); !vectorApproxEqual(got, V(2, 0)) {
+		t.Errorf("zero Slerp = %v, want the Lerp result %v", got, V(2, 0))
+	}
+}
+
+func TestVectorMoveTowardsEx(t *testing.T) {
+	from, to := V(0, 0), V(3, 4)
+	got, left := from.MoveTowardsEx(to, 2)
+	if !vectorApproxEqual(got, V(1.2, 1.6)) || left != 0 {
+		t.Errorf("short move = %v, %v, want %v, 0", got, left, V(1.2, 1.6))
+	}
+	got, left = from.MoveTowardsEx(to, 8)
+	if got != to || !approxEqual(left, 3) {
+		t.Errorf("overshooting move = %v, %v, want %v, 3", got, left, to)
+	}
+	got, left = from.MoveTowardsEx(to, 5)
+	if got != to || !approxEqual(left, 0) {
+		t.Errorf("exact move = %v, %v, want %v, 0", got, left, to)
+	}
+
+	// The leftover distance carries on to the next waypoint.
+	pos, left := from.MoveTowardsEx(V(1, 0), 3)
+	pos, left = pos.MoveTowardsEx(V(1, 10), left)
+	if !vectorApproxEqual(pos, V(1, 2)) || left != 0 {
+		t.Errorf("chained move = %v, %v, want %v, 0", pos, left, V(1, 2))
+	}
+}
+
+func TestMinMaxVector(t *testing.T) {
+	vs := []Vector{V(-3, 5), V(2, -7), V(0, 1)}
+	if got, want := MinVector(vs...), V(-3, -7); got != want {
+		t.Errorf("MinVector = %v, want %v", got, want)
+	}
+	if got, want := MaxVector(vs...), V(2, 5); got != want {
+		t.Errorf("MaxVector = %v, want %v", got, want)
+	}
+	if got := MinVector(V(4, -4)); got != V(4, -4) {
+		t.Errorf("single MinVector = %v, want %v", got, V(4, -4))
+	}
+	if got := MaxVector(V(4, -4)); got != V(4, -4) {
+		t.Errorf("single MaxVector = %v, want %v", got, V(4, -4))
+	}
+	if MinVector() != ZeroVector || MaxVector() != ZeroVector {
+		t.Error("MinVector() or MaxVector() with no arguments is not zero")
+	}
+}